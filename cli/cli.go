@@ -30,51 +30,101 @@ import (
 	"strings"
 )
 
-// ProcessStdin reads all data from standard input
-// and returns the input as a string
-func ProcessStdin() (string, error) {
-	// Read all data from standard input
-	input, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		return "", err
-	}
+// Line is a single line of input
+type Line struct {
+	Source string // The name of the input the line was read from
+	Number int    // The line number within the input, starting at 1
+	Text   string // The text of the line, without line ending
+}
 
-	// Return the input string on success
-	return string(input), nil
+// LineFunc is called once for every line of input, in the order
+// the lines are read. Returning an error stops the processing.
+type LineFunc func(line Line) error
+
+// ScanLines reads from r one line at a time and calls fn for each line.
+// Only the current line is held in memory, so arbitrarily large inputs
+// can be processed. Line endings ("\n" or "\r\n") are removed before
+// the line is passed to fn.
+func ScanLines(r io.Reader, fn LineFunc) error {
+	// Use a buffered reader instead of a bufio.Scanner to
+	// avoid the scanner's upper limit on the line length
+	reader := bufio.NewReaderSize(r, 64*1024)
+
+	for number := 1; ; number++ {
+		// Read the next line, including the line ending
+		text, err := reader.ReadString('\n')
+
+		// Process the line before checking for errors, since the
+		// last line of the input may not end with a line ending
+		if len(text) > 0 {
+			text = strings.TrimSuffix(text, "\n")
+			text = strings.TrimSuffix(text, "\r")
+			if err := fn(Line{Number: number, Text: text}); err != nil {
+				return err
+			}
+		}
+
+		if err != nil {
+			// Check if we reached the end of the input
+			if err == io.EOF {
+				return nil
+			}
+
+			// Return an error if we encountered an error other than EOF
+			return err
+		}
+	}
 }
 
-// ProcessInteractiveInput processes the interactive input
-// and extracts MAC addresses from the input string
-func ProcessInteractiveInput() (string, error) {
-	// A string for the user input
-	var input string
+// OpenInput returns the input to process. The input is
+// selected in the following order of precedence:
+//  1. The files specified by filenames, if any
+//  2. Standard input, if data is piped or redirected
+//  3. The command line arguments, joined by spaces
+//  4. Interactive input, if there are no command line arguments
+//
+// The caller is responsible for closing the returned input.
+func OpenInput(filenames []string, args []string) (Input, error) {
+	// Read input from files
+	if len(filenames) > 0 {
+		return NewFileInput(filenames), nil
+	}
 
-	// Create a scanner to read from standard input
-	scanner := bufio.NewScanner(os.Stdin)
+	// Process data from pipe or redirection (stdin), which may be compressed
+	if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) == 0 {
+		return NewStreamInput(StdinName, io.NopCloser(os.Stdin)), nil
+	}
 
-	// Tell the user how to finish the input
-	// based on the operating system
+	// If there are command line arguments, join them
+	// into a single string and use that as user input
+	if len(args) > 0 {
+		return NewReaderInput(ArgumentsName, io.NopCloser(strings.NewReader(strings.Join(args, " ")))), nil
+	}
+
+	// If there are no command line arguments,
+	// enter interactive mode and read user input
+	printInteractivePrompt()
+	return NewReaderInput(StdinName, io.NopCloser(os.Stdin)), nil
+}
+
+// printInteractivePrompt tells the user how to finish
+// the input based on the operating system
+func printInteractivePrompt() {
 	eofKeys := "CTRL+D"
 	if runtime.GOOS == "windows" {
 		eofKeys = "CTRL+Z"
 	}
 	fmt.Fprintf(os.Stderr, "Please enter the input text. Press %s to finish.\n", eofKeys)
+}
 
-	// Read each line from standard input as the user types
-	for scanner.Scan() {
-		input += fmt.Sprintf("%s\n", scanner.Text())
-	}
-
-	// Remove the trailing newline character
-	input = strings.TrimRight(input, "\n")
-
-	// Check for errors that may have occurred while reading
-	if err := scanner.Err(); err != nil {
-		return "", err
-	}
+// ProcessInteractiveInput processes the interactive input
+// and extracts MAC addresses from the input string
+func ProcessInteractiveInput() (string, error) {
+	// Tell the user how to finish the input
+	printInteractivePrompt()
 
-	// Return the input string on success
-	return input, nil
+	// Read each line from standard input as the user types
+	return readLines(os.Stdin)
 }
 
 // ProcessFile reads all data from the specified file
@@ -87,25 +137,27 @@ func ProcessFile(filename string) (string, error) {
 	}
 	defer file.Close()
 
-	// Create a scanner to read from the file
-	scanner := bufio.NewScanner(file)
-
-	// A string for the file contents
-	var input string
-
 	// Read each line from the file
-	for scanner.Scan() {
-		input += fmt.Sprintf("%s\n", scanner.Text())
-	}
-
-	// Remove the trailing newline character
-	input = strings.TrimRight(input, "\n")
+	return readLines(file)
+}
 
-	// Check for errors that may have occurred while reading
-	if err := scanner.Err(); err != nil {
+// readLines reads all lines from r and returns them joined by
+// newline characters, without any trailing newline characters
+func readLines(r io.Reader) (string, error) {
+	// A builder for the input, growing without
+	// copying the previously read lines
+	var input strings.Builder
+
+	// Read each line from the reader
+	err := ScanLines(r, func(line Line) error {
+		input.WriteString(line.Text)
+		input.WriteByte('\n')
+		return nil
+	})
+	if err != nil {
 		return "", err
 	}
 
-	// Return the input string on success
-	return input, nil
+	// Remove the trailing newline character
+	return strings.TrimRight(input.String(), "\n"), nil
 }
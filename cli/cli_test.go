@@ -1,72 +1,14 @@
 package cli_test
 
 import (
+	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/bitcanon/mactool/cli"
 )
 
-// TestProcessStdin tests the ProcessStdin function
-// by redirecting stdin to a pipe and writing test
-// data to the pipe to simulate user input
-func TestProcessStdin(t *testing.T) {
-	// Setup test cases
-	tests := []struct {
-		name        string
-		input       string
-		expected    string
-		expectedErr error
-	}{
-		{
-			name:     "ValidSingleLineInput",
-			input:    `First line of input from interactive user input`,
-			expected: `First line of input from interactive user input`,
-		},
-		{
-			name: "ValidMultiLineInput",
-			input: `First line of input from interactive user input
-Second line of input from interactive user input`,
-			expected: `First line of input from interactive user input
-Second line of input from interactive user input`,
-		},
-		{
-			name:     "EmptyInput",
-			input:    "",
-			expected: "",
-		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			// Redirect stdin for the test
-			originalStdin := os.Stdin
-
-			// Restore stdin when the test is done
-			defer func() { os.Stdin = originalStdin }()
-
-			// Create a pipe for stdin and write test data to it
-			r, w, _ := os.Pipe()
-			os.Stdin = r
-			w.WriteString(test.input)
-			w.Close()
-			defer r.Close()
-
-			// Process stdin using the cli package
-			output, err := cli.ProcessStdin()
-			if err != nil {
-				t.Errorf("error returned from ProcessStdin(): %v", err)
-				return
-			}
-
-			// Compare the results to the expected values
-			if output != test.expected {
-				t.Errorf("expected %q, but got %q", test.expected, output)
-			}
-		})
-	}
-}
-
 // TestProcessInteractiveInput tests the ProcessInteractiveInput function
 // by redirecting stdin to a pipe and writing test data to the pipe to
 // simulate user input
@@ -194,3 +136,87 @@ func TestProcessFile(t *testing.T) {
 		}
 	})
 }
+
+// TestScanLines tests the ScanLines function by reading
+// test data from a reader and collecting the lines passed
+// to the callback function
+func TestScanLines(t *testing.T) {
+	// Setup test cases
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "EmptyInput",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "SingleLineWithoutNewline",
+			input:    "00:00:5e:00:53:01",
+			expected: []string{"00:00:5e:00:53:01"},
+		},
+		{
+			name:     "MultipleLines",
+			input:    "Line 1\nLine 2\nLine 3\n",
+			expected: []string{"Line 1", "Line 2", "Line 3"},
+		},
+		{
+			name:     "WindowsLineEndings",
+			input:    "Line 1\r\nLine 2\r\n",
+			expected: []string{"Line 1", "Line 2"},
+		},
+		{
+			name:     "EmptyLines",
+			input:    "Line 1\n\nLine 3",
+			expected: []string{"Line 1", "", "Line 3"},
+		},
+		{
+			name:     "LineLongerThanBuffer",
+			input:    strings.Repeat("a", 256*1024) + "\nb",
+			expected: []string{strings.Repeat("a", 256*1024), "b"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Collect the lines passed to the callback
+			var lines []string
+			err := cli.ScanLines(strings.NewReader(test.input), func(line cli.Line) error {
+				lines = append(lines, line.Text)
+				return nil
+			})
+			if err != nil {
+				t.Errorf("error returned from ScanLines(): %v", err)
+				return
+			}
+
+			// Compare the results to the expected values
+			if len(lines) != len(test.expected) {
+				t.Fatalf("expected %d lines, but got %d", len(test.expected), len(lines))
+			}
+			for i := range lines {
+				if lines[i] != test.expected[i] {
+					t.Errorf("expected line %d to be %q, but got %q", i+1, test.expected[i], lines[i])
+				}
+			}
+		})
+	}
+
+	// Test that an error returned from the callback stops the scanning
+	t.Run("CallbackError", func(t *testing.T) {
+		expectedErr := errors.New("stop")
+		calls := 0
+		err := cli.ScanLines(strings.NewReader("Line 1\nLine 2\n"), func(line cli.Line) error {
+			calls++
+			return expectedErr
+		})
+		if err != expectedErr {
+			t.Errorf("expected %v, but got %v", expectedErr, err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 call, but got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cli
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// inputFormat is the format of an input file, as detected by its magic bytes
+type inputFormat int
+
+const (
+	plainFormat inputFormat = iota
+	gzipFormat
+	bzip2Format
+	zstdFormat
+	zipFormat
+	tarFormat
+)
+
+// headerSize is the number of bytes needed to detect the input format.
+// The tar magic is the largest, found at offset 257 of the archive.
+const headerSize = 262
+
+// detectFormat detects the format of the input from its first bytes
+func detectFormat(header []byte) inputFormat {
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return gzipFormat
+	case bytes.HasPrefix(header, []byte("BZh")) && len(header) > 3 && '1' <= header[3] && header[3] <= '9':
+		// BZh is followed by the block size, 1 to 9 (100k to 900k)
+		return bzip2Format
+	case bytes.HasPrefix(header, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		return zstdFormat
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return zipFormat
+	case len(header) >= headerSize && bytes.HasPrefix(header[257:], []byte("ustar")):
+		return tarFormat
+	}
+	return plainFormat
+}
+
+// scanFile calls fn for every line in the specified file. Compressed files
+// are decompressed, and the files inside archives are read one by one, with
+// the name of each file inside the archive appended to the source of its
+// lines (for example "bundle.zip:logs/syslog").
+func scanFile(filename string, fn LineFunc) error {
+	// Open the input file
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Zip archives are read using random access, which the file supports
+	header := make([]byte, headerSize)
+	n, err := file.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if detectFormat(header[:n]) == zipFormat {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		return scanZip(filename, file, info.Size(), fn)
+	}
+
+	// Read each line from the file, decompressing it as needed
+	return scanStream(filename, file, fn)
+}
+
+// scanStream calls fn for every line in the stream r, named name,
+// after decompressing or unpacking it as detected by its magic bytes
+func scanStream(name string, r io.Reader, fn LineFunc) error {
+	// Peek at the first bytes of the stream to detect its format,
+	// without consuming them
+	reader := bufio.NewReaderSize(r, 64*1024)
+	header, err := reader.Peek(headerSize)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	switch detectFormat(header) {
+	case gzipFormat:
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		return scanStream(name, gz, fn)
+
+	case bzip2Format:
+		return scanStream(name, bzip2.NewReader(reader), fn)
+
+	case zstdFormat:
+		zr, err := zstd.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		return scanStream(name, zr, fn)
+
+	case zipFormat:
+		// Zip archives require random access, so an archive read from a
+		// pipe, another archive or a compressed file is spooled to a
+		// temporary file first, rather than held in memory
+		spool, err := os.CreateTemp("", "mactool-*.zip")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+		size, err := io.Copy(spool, reader)
+		if err != nil {
+			return err
+		}
+		return scanZip(name, spool, size, fn)
+
+	case tarFormat:
+		return scanTar(name, tar.NewReader(reader), fn)
+	}
+
+	// Read each line from the plain text stream
+	return NewReaderInput(name, io.NopCloser(reader)).Scan(fn)
+}
+
+// scanZip calls fn for every line in each of the files in the zip archive
+func scanZip(name string, r io.ReaderAt, size int64, fn LineFunc) error {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	// Read each of the files in the archive, skipping directories
+	for _, entry := range archive.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		if err := scanZipEntry(name+":"+entry.Name, entry, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanZipEntry calls fn for every line in the file inside a zip archive
+func scanZipEntry(name string, entry *zip.File, fn LineFunc) error {
+	file, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return scanStream(name, file, fn)
+}
+
+// scanTar calls fn for every line in each of the files in the tar archive
+func scanTar(name string, archive *tar.Reader, fn LineFunc) error {
+	for {
+		entry, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Read the regular files in the archive, skipping directories and links
+		if entry.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := scanStream(name+":"+entry.Name, archive, fn); err != nil {
+			return err
+		}
+	}
+}
+
+// IsCompressed reports whether the specified file is compressed
+// or an archive, as detected by the magic bytes of the file
+func IsCompressed(filename string) (bool, error) {
+	// Open the file
+	file, err := os.Open(filename)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	// Read the first bytes of the file and detect its format
+	header := make([]byte, headerSize)
+	n, err := file.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	return detectFormat(header[:n]) != plainFormat, nil
+}
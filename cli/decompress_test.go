@@ -0,0 +1,267 @@
+package cli_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// gzipData compresses the data using gzip
+func gzipData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write(data)
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to compress data: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// zstdData compresses the data using zstd
+func zstdData(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("failed to create zstd writer: %v", err)
+	}
+	w.Write(data)
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to compress data: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// zipData creates a zip archive containing the files, in order
+func zipData(t *testing.T, names []string, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add file to zip archive: %v", err)
+		}
+		f.Write(files[name])
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to create zip archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// tarData creates a tar archive containing the files, in order
+func tarData(t *testing.T, names []string, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	w.WriteHeader(&tar.Header{Name: "logs/", Typeflag: tar.TypeDir, Mode: 0755})
+	for _, name := range names {
+		w.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(files[name]))})
+		w.Write(files[name])
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to create tar archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestFileInputDecompression tests that compressed files and
+// archives are read transparently by the file input
+func TestFileInputDecompression(t *testing.T) {
+	plain := []byte("00:00:5e:00:53:01\n00:00:5e:00:53:02\n")
+	files := map[string][]byte{
+		"logs/a.log": []byte("00:00:5e:00:53:0a\n"),
+		"logs/b.log": gzipData(t, []byte("00:00:5e:00:53:0b\n")),
+	}
+	names := []string{"logs/a.log", "logs/b.log"}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected []cli.Line
+	}{
+		{
+			name: "Plain",
+			data: plain,
+			expected: []cli.Line{
+				{Source: "input", Number: 1, Text: "00:00:5e:00:53:01"},
+				{Source: "input", Number: 2, Text: "00:00:5e:00:53:02"},
+			},
+		},
+		{
+			name: "Gzip",
+			data: gzipData(t, plain),
+			expected: []cli.Line{
+				{Source: "input", Number: 1, Text: "00:00:5e:00:53:01"},
+				{Source: "input", Number: 2, Text: "00:00:5e:00:53:02"},
+			},
+		},
+		{
+			name: "Zstd",
+			data: zstdData(t, plain),
+			expected: []cli.Line{
+				{Source: "input", Number: 1, Text: "00:00:5e:00:53:01"},
+				{Source: "input", Number: 2, Text: "00:00:5e:00:53:02"},
+			},
+		},
+		{
+			name: "Zip",
+			data: zipData(t, names, files),
+			expected: []cli.Line{
+				{Source: "input:logs/a.log", Number: 1, Text: "00:00:5e:00:53:0a"},
+				{Source: "input:logs/b.log", Number: 1, Text: "00:00:5e:00:53:0b"},
+			},
+		},
+		{
+			name: "TarGzip",
+			data: gzipData(t, tarData(t, names, files)),
+			expected: []cli.Line{
+				{Source: "input:logs/a.log", Number: 1, Text: "00:00:5e:00:53:0a"},
+				{Source: "input:logs/b.log", Number: 1, Text: "00:00:5e:00:53:0b"},
+			},
+		},
+		{
+			name: "ZipInsideTar",
+			data: tarData(t, []string{"bundle.zip"}, map[string][]byte{"bundle.zip": zipData(t, names, files)}),
+			expected: []cli.Line{
+				{Source: "input:bundle.zip:logs/a.log", Number: 1, Text: "00:00:5e:00:53:0a"},
+				{Source: "input:bundle.zip:logs/b.log", Number: 1, Text: "00:00:5e:00:53:0b"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Write the test data to a file named "input"
+			dir := t.TempDir()
+			filename := filepath.Join(dir, "input")
+			if err := os.WriteFile(filename, test.data, 0644); err != nil {
+				t.Fatalf("failed to create file: %v", err)
+			}
+
+			// Read all lines from the file
+			var lines []cli.Line
+			err := cli.NewFileInput([]string{filename}).Scan(func(line cli.Line) error {
+				line.Source, _ = filepath.Rel(dir, line.Source)
+				lines = append(lines, line)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("error returned from Scan(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if !reflect.DeepEqual(lines, test.expected) {
+				t.Errorf("expected %v, but got %v", test.expected, lines)
+			}
+		})
+	}
+
+	// Test reading a bzip2 compressed file
+	t.Run("Bzip2", func(t *testing.T) {
+		var lines []string
+		err := cli.NewFileInput([]string{"../testdata/dhcpd.log.bz2"}).Scan(func(line cli.Line) error {
+			lines = append(lines, line.Text)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("error returned from Scan(): %v", err)
+		}
+
+		expected := []string{
+			"Oct 18 12:00:01 relay dhcpd: DHCPACK to 00:00:5e:00:53:01 via eth0",
+			"Oct 18 12:00:02 relay dhcpd: DHCPACK to 00:00:5e:00:53:02 via eth0",
+		}
+		if !reflect.DeepEqual(lines, expected) {
+			t.Errorf("expected %q, but got %q", expected, lines)
+		}
+	})
+}
+
+// TestStreamInputDecompression tests that a stream, such as piped standard
+// input, is decompressed as detected by its magic bytes
+func TestStreamInputDecompression(t *testing.T) {
+	plain := []byte("00:00:5e:00:53:01\n00:00:5e:00:53:02\n")
+	expected := []cli.Line{
+		{Source: "stdin", Number: 1, Text: "00:00:5e:00:53:01"},
+		{Source: "stdin", Number: 2, Text: "00:00:5e:00:53:02"},
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"Plain", plain},
+		{"Gzip", gzipData(t, plain)},
+		{"Zstd", zstdData(t, plain)},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Read all lines from the stream
+			var lines []cli.Line
+			input := cli.NewStreamInput("stdin", io.NopCloser(bytes.NewReader(test.data)))
+			err := input.Scan(func(line cli.Line) error {
+				lines = append(lines, line)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("error returned from Scan(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if !reflect.DeepEqual(lines, expected) {
+				t.Errorf("expected %v, but got %v", expected, lines)
+			}
+		})
+	}
+	// A zip archive in the stream is read file by file
+	t.Run("Zip", func(t *testing.T) {
+		var lines []cli.Line
+		data := zipData(t, []string{"macs.txt"}, map[string][]byte{"macs.txt": plain})
+		input := cli.NewStreamInput("stdin", io.NopCloser(bytes.NewReader(data)))
+		err := input.Scan(func(line cli.Line) error {
+			lines = append(lines, line)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("error returned from Scan(): %v", err)
+		}
+		expected := []cli.Line{
+			{Source: "stdin:macs.txt", Number: 1, Text: "00:00:5e:00:53:01"},
+			{Source: "stdin:macs.txt", Number: 2, Text: "00:00:5e:00:53:02"},
+		}
+		if !reflect.DeepEqual(lines, expected) {
+			t.Errorf("expected %v, but got %v", expected, lines)
+		}
+	})
+
+	// Plain text starting with the bzip2 magic is not decompressed
+	t.Run("PlainStartingWithBZh", func(t *testing.T) {
+		var lines []string
+		input := cli.NewStreamInput("stdin", io.NopCloser(bytes.NewReader([]byte("BZhost 00:00:5e:00:53:01\n"))))
+		err := input.Scan(func(line cli.Line) error {
+			lines = append(lines, line.Text)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("error returned from Scan(): %v", err)
+		}
+		if expected := []string{"BZhost 00:00:5e:00:53:01"}; !reflect.DeepEqual(lines, expected) {
+			t.Errorf("expected %q, but got %q", expected, lines)
+		}
+	})
+}
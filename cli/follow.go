@@ -0,0 +1,154 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// FollowPollInterval is how often a followed file
+// is checked for new data, truncation and rotation
+var FollowPollInterval = 500 * time.Millisecond
+
+// followReader reads data appended to a file, like tail -F
+type followReader struct {
+	ctx      context.Context
+	filename string
+	file     *os.File
+	offset   int64
+}
+
+// FollowFile opens the specified file and returns a reader that, like
+// tail -F, keeps returning data as it is appended to the file. Reading
+// starts at the current end of the file. If the file is truncated, reading
+// restarts from the beginning, and if the file is replaced (for example by
+// log rotation), the new file is read from the beginning. The reader returns
+// io.EOF when the context is cancelled.
+func FollowFile(ctx context.Context, filename string) (io.ReadCloser, error) {
+	// Open the file to follow
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip the data already in the file
+	offset, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &followReader{
+		ctx:      ctx,
+		filename: filename,
+		file:     file,
+		offset:   offset,
+	}, nil
+}
+
+// Read implements the io.Reader interface. It blocks until new
+// data is available in the file or the context is cancelled.
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		// Read any data appended to the file
+		n, err := f.file.Read(p)
+		if n > 0 {
+			f.offset += int64(n)
+			return n, nil
+		}
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		// No new data, so check if the file was truncated or rotated
+		// and read from the start of the file right away if it was
+		changed, err := f.checkFile()
+		if err != nil {
+			return 0, err
+		}
+		if changed {
+			continue
+		}
+
+		// Wait for more data to be written to the file
+		select {
+		case <-f.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(FollowPollInterval):
+		}
+	}
+}
+
+// checkFile checks whether the followed file has been replaced or
+// truncated, and if so, prepares for reading from the beginning of
+// the file. It returns true if the file was replaced or truncated.
+func (f *followReader) checkFile() (bool, error) {
+	// Get the file currently found at the path
+	info, err := os.Stat(f.filename)
+	if os.IsNotExist(err) {
+		// The file may be missing for a short while during
+		// log rotation, so wait for it to be created again
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	// Get the file currently being read
+	current, err := f.file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	// Switch to the new file if the file was replaced
+	if !os.SameFile(info, current) {
+		file, err := os.Open(f.filename)
+		if os.IsNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		f.file.Close()
+		f.file = file
+		f.offset = 0
+		return true, nil
+	}
+
+	// Start over from the beginning if the file was truncated
+	if current.Size() < f.offset {
+		if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+			return false, err
+		}
+		f.offset = 0
+		return true, nil
+	}
+
+	// The file is unchanged
+	return false, nil
+}
+
+// Close closes the file being followed
+func (f *followReader) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,101 @@
+package cli_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitcanon/mactool/cli"
+)
+
+// TestFollowFile tests the FollowFile function by appending,
+// truncating and rotating a file while it is being followed
+func TestFollowFile(t *testing.T) {
+	// Check for changes often to keep the test fast
+	originalInterval := cli.FollowPollInterval
+	cli.FollowPollInterval = 10 * time.Millisecond
+	defer func() { cli.FollowPollInterval = originalInterval }()
+
+	// Create a log file with some existing content
+	filename := filepath.Join(t.TempDir(), "syslog")
+	if err := os.WriteFile(filename, []byte("existing line\n"), 0644); err != nil {
+		t.Fatalf("failed to create log file: %v", err)
+	}
+
+	// Start following the file
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, err := cli.FollowFile(ctx, filename)
+	if err != nil {
+		t.Fatalf("error returned from FollowFile(): %v", err)
+	}
+	defer reader.Close()
+
+	// Read lines from the followed file in the background
+	lines := make(chan string)
+	done := make(chan error)
+	go func() {
+		done <- cli.ScanLines(reader, func(line cli.Line) error {
+			lines <- line.Text
+			return nil
+		})
+	}()
+
+	// expectLine waits for the next line read from the followed file
+	expectLine := func(expected string) {
+		t.Helper()
+		select {
+		case line := <-lines:
+			if line != expected {
+				t.Errorf("expected %q, but got %q", expected, line)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", expected)
+		}
+	}
+
+	// appendLine appends a line to the followed file
+	appendLine := func(line string) {
+		t.Helper()
+		file, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			t.Fatalf("failed to open log file: %v", err)
+		}
+		defer file.Close()
+		if _, err := file.WriteString(line + "\n"); err != nil {
+			t.Fatalf("failed to write to log file: %v", err)
+		}
+	}
+
+	// Lines appended to the file are read, existing lines are not
+	appendLine("first appended line")
+	expectLine("first appended line")
+	appendLine("second appended line")
+	expectLine("second appended line")
+
+	// Lines written after truncating the file are read from the start
+	if err := os.Truncate(filename, 0); err != nil {
+		t.Fatalf("failed to truncate log file: %v", err)
+	}
+	appendLine("after truncate")
+	expectLine("after truncate")
+
+	// Lines written to a new file after rotation are read from the start
+	if err := os.Rename(filename, filename+".1"); err != nil {
+		t.Fatalf("failed to rotate log file: %v", err)
+	}
+	appendLine("after rotation")
+	expectLine("after rotation")
+
+	// Cancelling the context ends the input
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("error returned from ScanLines(): %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the input to end")
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cli
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Names of the inputs that are not read from files
+const (
+	StdinName     = "(standard input)"
+	ArgumentsName = "(arguments)"
+)
+
+// Input is a source of input lines, such as standard input or a list of files
+type Input interface {
+	// Scan calls fn for every line of the input, in order
+	Scan(fn LineFunc) error
+
+	// Close releases the resources held by the input
+	Close() error
+}
+
+// readerInput is an Input reading lines from a single reader
+type readerInput struct {
+	name string
+	r    io.ReadCloser
+}
+
+// NewReaderInput returns an Input reading lines from r. The
+// name is used as the source of the lines read from r.
+func NewReaderInput(name string, r io.ReadCloser) Input {
+	return &readerInput{name: name, r: r}
+}
+
+// Scan calls fn for every line read from the reader
+func (in *readerInput) Scan(fn LineFunc) error {
+	return ScanLines(in.r, func(line Line) error {
+		line.Source = in.name
+		return fn(line)
+	})
+}
+
+// Close closes the reader
+func (in *readerInput) Close() error {
+	return in.r.Close()
+}
+
+// streamInput is an Input reading lines from a single stream,
+// which may be compressed or an archive
+type streamInput struct {
+	name string
+	r    io.ReadCloser
+}
+
+// NewStreamInput returns an Input reading lines from r, such as piped
+// standard input. Compressed streams (gzip, bzip2 and zstd) and archives
+// (zip and tar) are detected by their magic bytes and read transparently.
+// The name is used as the source of the lines read from r.
+func NewStreamInput(name string, r io.ReadCloser) Input {
+	return &streamInput{name: name, r: r}
+}
+
+// Scan calls fn for every line read from the stream
+func (in *streamInput) Scan(fn LineFunc) error {
+	return scanStream(in.name, in.r, fn)
+}
+
+// Close closes the stream
+func (in *streamInput) Close() error {
+	return in.r.Close()
+}
+
+// fileInput is an Input reading lines from a list of files
+type fileInput struct {
+	filenames []string
+}
+
+// NewFileInput returns an Input reading lines from each of the
+// files in order. Each file is opened when it is about to be read,
+// and closed when all of its lines have been processed. Compressed
+// files (gzip, bzip2 and zstd) and archives (zip and tar) are read
+// transparently.
+func NewFileInput(filenames []string) Input {
+	return &fileInput{filenames: filenames}
+}
+
+// Scan calls fn for every line in each of the files
+func (in *fileInput) Scan(fn LineFunc) error {
+	for _, filename := range in.filenames {
+		if err := scanFile(filename, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close does nothing, since the files are closed after being read
+func (in *fileInput) Close() error {
+	return nil
+}
+
+// ExpandFiles expands the specified paths into a list of files. Each path
+// may be a file, a directory or a glob pattern (for example "logs/*.log").
+// Directories are only read when recursive is true, in which case all
+// files within the directory tree are included in lexical order.
+func ExpandFiles(paths []string, recursive bool) ([]string, error) {
+	var filenames []string
+
+	for _, path := range paths {
+		// Expand glob patterns into the matching paths
+		matches := []string{path}
+		isPattern := strings.ContainsAny(path, "*?[")
+		if isPattern {
+			var err error
+			matches, err = filepath.Glob(path)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", path, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match pattern %q", path)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+
+			// Add regular files as they are
+			if !info.IsDir() {
+				filenames = append(filenames, match)
+				continue
+			}
+
+			// Walk directories if recursion is enabled. Directories
+			// matching a glob pattern are skipped otherwise.
+			if !recursive {
+				if isPattern {
+					continue
+				}
+				return nil, fmt.Errorf("%s is a directory (use --recursive to read the files in it)", match)
+			}
+			files, err := walkDirectory(match)
+			if err != nil {
+				return nil, err
+			}
+			filenames = append(filenames, files...)
+		}
+	}
+
+	// Return the list of files
+	return filenames, nil
+}
+
+// walkDirectory returns all regular files in the directory tree
+func walkDirectory(root string) ([]string, error) {
+	var filenames []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Only include regular files, skipping devices, sockets etc.
+		if d.Type().IsRegular() {
+			filenames = append(filenames, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The files are in lexical order, as visited by WalkDir
+	return filenames, nil
+}
@@ -0,0 +1,161 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bitcanon/mactool/cli"
+)
+
+// createFiles creates the files with the given content in the
+// directory dir, creating any subdirectories as needed
+func createFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+	}
+}
+
+// TestExpandFiles tests the ExpandFiles function with
+// files, glob patterns and directories
+func TestExpandFiles(t *testing.T) {
+	// Create a directory tree with some files
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"a.log":         "",
+		"b.log":         "",
+		"c.txt":         "",
+		"backup/r1.cfg": "",
+		"backup/x/r2":   "",
+	})
+
+	// Setup test cases
+	testCases := []struct {
+		name      string
+		paths     []string
+		recursive bool
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "NoPaths",
+			paths:    nil,
+			expected: nil,
+		},
+		{
+			name:     "SingleFile",
+			paths:    []string{"c.txt"},
+			expected: []string{"c.txt"},
+		},
+		{
+			name:     "MultipleFilesInOrder",
+			paths:    []string{"c.txt", "a.log"},
+			expected: []string{"c.txt", "a.log"},
+		},
+		{
+			name:     "GlobPattern",
+			paths:    []string{"*.log"},
+			expected: []string{"a.log", "b.log"},
+		},
+		{
+			name:      "GlobPatternWithoutMatches",
+			paths:     []string{"*.gz"},
+			expectErr: true,
+		},
+		{
+			name:     "GlobPatternSkipsDirectories",
+			paths:    []string{"b*"},
+			expected: []string{"b.log"},
+		},
+		{
+			name:      "DirectoryWithoutRecursive",
+			paths:     []string{"backup"},
+			expectErr: true,
+		},
+		{
+			name:      "DirectoryWithRecursive",
+			paths:     []string{"backup"},
+			recursive: true,
+			expected:  []string{"backup/r1.cfg", "backup/x/r2"},
+		},
+		{
+			name:      "MissingFile",
+			paths:     []string{"missing.txt"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Make the paths absolute within the temporary directory
+			var paths []string
+			for _, p := range test.paths {
+				paths = append(paths, filepath.Join(dir, p))
+			}
+			var expected []string
+			for _, p := range test.expected {
+				expected = append(expected, filepath.Join(dir, filepath.FromSlash(p)))
+			}
+
+			// Expand the paths into files
+			actual, err := cli.ExpandFiles(paths, test.recursive)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error returned from ExpandFiles(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected %q, but got %q", expected, actual)
+			}
+		})
+	}
+}
+
+// TestFileInput tests that the lines of all files are
+// read in order, along with their source and line number
+func TestFileInput(t *testing.T) {
+	// Create two input files
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"first.txt":  "Line 1\nLine 2\n",
+		"second.txt": "Line 1",
+	})
+	first := filepath.Join(dir, "first.txt")
+	second := filepath.Join(dir, "second.txt")
+
+	// Read all lines from the files
+	var lines []cli.Line
+	input := cli.NewFileInput([]string{first, second})
+	defer input.Close()
+	err := input.Scan(func(line cli.Line) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error returned from Scan(): %v", err)
+	}
+
+	// Compare the results to the expected values
+	expected := []cli.Line{
+		{Source: first, Number: 1, Text: "Line 1"},
+		{Source: first, Number: 2, Text: "Line 2"},
+		{Source: second, Number: 1, Text: "Line 1"},
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("expected %v, but got %v", expected, lines)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bitcanon/mactool/oui"
+	"github.com/spf13/viper"
+)
+
+// Categories are extended with prefixes from the config file, such as:
+//
+//	categories:
+//	  virtual:
+//	    - 02:00:00:*
+//	    - prefix: 0a:58:0a:*
+//	      description: Kubernetes pod
+//	  lab:
+//	    - 00:1a:2b:00:00:00/40
+//
+// A prefix is either a string, or a map with the prefix and an optional
+// description that is shown in place of the vendor when it is not found.
+//
+// Organizations are put into device categories by the built-in rules,
+// which take second place to the rules of the file set by category-rules:
+//
+//	category-rules: ~/.config/mactool/categories.rules
+
+// loadCategories returns the built-in categories extended with the
+// rules file and the prefixes of the categories in the config file
+func loadCategories() (*oui.Categories, error) {
+	categories := oui.NewCategories()
+
+	// Add the organization rules of the rules file, if set
+	if filename := viper.GetString("category-rules"); filename != "" {
+		filename, err := expandHome(filename)
+		if err != nil {
+			return nil, err
+		}
+		file, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		if err := categories.LoadOrganizationRules(file); err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+	}
+
+	// Add the categories in a fixed order, so that errors are reproducible
+	settings := viper.GetStringMap("categories")
+	var names []string
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// Each category is a list of prefixes
+		prefixes, ok := settings[name].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("category %q: must be a list of prefixes", name)
+		}
+		for _, entry := range prefixes {
+			prefix, description, err := parseCategoryPrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("category %q: %w", name, err)
+			}
+			if err := categories.AddPrefix(name, prefix, description); err != nil {
+				return nil, fmt.Errorf("category %q: %w", name, err)
+			}
+		}
+	}
+	return categories, nil
+}
+
+// expandHome replaces a leading ~ in the path with the home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// parseCategoryPrefix returns the prefix and description of
+// a prefix of a category, given as a string or as a map
+func parseCategoryPrefix(entry interface{}) (string, string, error) {
+	switch v := entry.(type) {
+	case string:
+		return v, "", nil
+	case map[string]interface{}:
+		prefix, _ := v["prefix"].(string)
+		if prefix == "" {
+			return "", "", fmt.Errorf("missing prefix in %v", v)
+		}
+		description, _ := v["description"].(string)
+		return prefix, description, nil
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for key, value := range v {
+			converted[fmt.Sprint(key)] = value
+		}
+		return parseCategoryPrefix(converted)
+	default:
+		return "", "", fmt.Errorf("invalid prefix %v", entry)
+	}
+}
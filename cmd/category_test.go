@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestLoadCategoriesRulesFile tests that the rules file of the config
+// file is loaded, with a leading ~ expanded to the home directory
+func TestLoadCategoriesRulesFile(t *testing.T) {
+	// Create a rules file in a temporary home directory
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	if err := os.MkdirAll(filepath.Join(home, ".config", "mactool"), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	rules := filepath.Join(home, ".config", "mactool", "categories.rules")
+	if err := os.WriteFile(rules, []byte("mobile Hon Hai Precision\n"), 0644); err != nil {
+		t.Fatalf("failed to create rules file: %v", err)
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		filename string
+		err      bool
+	}{
+		{"AbsolutePath", rules, false},
+		{"HomeDirectory", "~/.config/mactool/categories.rules", false},
+		{"Missing", "~/missing.rules", true},
+	}
+
+	defer viper.Set("category-rules", "")
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("category-rules", test.filename)
+
+			// Load the categories with the rules file
+			categories, err := loadCategories()
+			if test.err {
+				if err == nil {
+					t.Errorf("loadCategories() returned no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadCategories() returned error: %v", err)
+			}
+
+			// Check that the rules of the file are used
+			if category, _ := categories.MatchOrganization("Hon Hai Precision Ind. Co.,Ltd."); category != "mobile" {
+				t.Errorf("MatchOrganization() = %q, want %q", category, "mobile")
+			}
+		})
+	}
+}
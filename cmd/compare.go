@@ -0,0 +1,317 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/debug"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/bitcanon/mactool/oui"
+	"github.com/bitcanon/mactool/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Errors returned when the compare command is used incorrectly
+var errCompareStdinTwice = errors.New("only one of the inputs can be standard input (-)")
+var errInvalidCompareSet = errors.New("invalid set")
+
+// compareSets are the sets of MAC addresses reported by the compare
+// command, in the order they are printed, with the mark of each set
+var compareSets = []struct {
+	name string
+	mark string
+}{
+	{"removed", "-"},
+	{"added", "+"},
+	{"common", "="},
+}
+
+// compareEntry is a MAC address in one of the sets, as written in
+// JSON format, with its vendor if the --vendor flag is set
+type compareEntry struct {
+	MacAddress   string `json:"mac"`
+	Organization string `json:"organization,omitempty"`
+}
+
+// collectAddresses returns the MAC addresses found in the input, keyed by
+// their value, as colon-separated hex digits in lower case. MAC addresses
+// in different notations, such as 0011.2233.4455 and 00-11-22-33-44-55,
+// are the same MAC address.
+func collectAddresses(scanner mac.Scanner, input cli.Input) (map[string]string, error) {
+	addresses := map[string]string{}
+	err := input.Scan(func(line cli.Line) error {
+		for _, m := range scanner.FindAll(line.Text) {
+			canonical, err := mac.FormatMacAddress(m.Address(), mac.MacFormat{Case: mac.Lower, Delimiter: mac.Colon, GroupSize: mac.GroupSizeTwo})
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.Text, err)
+			}
+			addresses[addressKey(canonical)] = canonical
+		}
+		return nil
+	})
+	return addresses, err
+}
+
+// compareAction finds the MAC addresses in the old and new inputs and
+// prints the MAC addresses that were removed (only in the old input),
+// added (only in the new input) and common to both. If ouiCsvFile is
+// not nil, the MAC addresses are printed with their vendors.
+func compareAction(out io.Writer, ouiCsvFile io.Reader, oldInput cli.Input, newInput cli.Input) error {
+	// Print only the sets of the --only flag, or all of them
+	only := viper.GetStringSlice("compare.only")
+	for _, name := range only {
+		found := false
+		for _, set := range compareSets {
+			found = found || set.name == name
+		}
+		if !found {
+			return fmt.Errorf("--only %s: %w; must be one of removed, added, common", name, errInvalidCompareSet)
+		}
+	}
+
+	// Load the OUI database and the categories to find the vendors
+	var db *oui.OuiDb
+	var categories *oui.Categories
+	if ouiCsvFile != nil {
+		var err error
+		if db, err = oui.LoadDatabase(ouiCsvFile); err != nil {
+			return err
+		}
+		if categories, err = loadCategories(); err != nil {
+			return err
+		}
+	}
+
+	// Find the MAC addresses in both inputs
+	scanner, err := newScanner("compare")
+	if err != nil {
+		return err
+	}
+	oldAddresses, err := collectAddresses(scanner, oldInput)
+	if err != nil {
+		return err
+	}
+	newAddresses, err := collectAddresses(scanner, newInput)
+	if err != nil {
+		return err
+	}
+
+	// Put each MAC address into the removed, added or common set
+	sets := map[string][]string{}
+	for key, address := range oldAddresses {
+		if _, ok := newAddresses[key]; ok {
+			sets["common"] = append(sets["common"], address)
+		} else {
+			sets["removed"] = append(sets["removed"], address)
+		}
+	}
+	for key, address := range newAddresses {
+		if _, ok := oldAddresses[key]; !ok {
+			sets["added"] = append(sets["added"], address)
+		}
+	}
+
+	// Get the entries of the sets to print, sorted by MAC address
+	entries := map[string][]compareEntry{}
+	for _, set := range compareSets {
+		if len(only) > 0 && !containsString(only, set.name) {
+			continue
+		}
+		addresses := sets[set.name]
+		sort.Slice(addresses, func(i, j int) bool {
+			return compareAddresses(addresses[i], addresses[j]) < 0
+		})
+		entries[set.name] = []compareEntry{}
+		for _, address := range addresses {
+			entry := compareEntry{MacAddress: address}
+			if db != nil {
+				vendor, _, _, err := findVendor(db, categories, address)
+				if err != nil {
+					return err
+				}
+				if vendor != nil {
+					entry.Organization = vendor.Organization
+				}
+			}
+			entries[set.name] = append(entries[set.name], entry)
+		}
+	}
+
+	// Write in JSON format if the --json flag is set
+	w := bufio.NewWriter(out)
+	if viper.GetBool("compare.json") {
+		if err := json.NewEncoder(w).Encode(entries); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+
+	// Print each MAC address with the mark of its set, like diff
+	for _, set := range compareSets {
+		for _, entry := range entries[set.name] {
+			if entry.Organization != "" {
+				fmt.Fprintf(w, "%s %s (%s)\n", set.mark, entry.MacAddress, entry.Organization)
+			} else {
+				fmt.Fprintf(w, "%s %s\n", set.mark, entry.MacAddress)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// openCompareInput opens the input file to compare, or standard input if
+// the name is -. Compressed input and archives are read transparently.
+func openCompareInput(name string) cli.Input {
+	if name == "-" {
+		return cli.NewStreamInput(cli.StdinName, io.NopCloser(os.Stdin))
+	}
+	return cli.NewFileInput([]string{name})
+}
+
+// Example help text for the compare command
+const compareExample = `  mactool compare yesterday.txt today.txt
+  show mac address-table | mactool compare before-migration.txt -
+  mactool compare --only removed --vendor old.log.gz new.log.gz
+  mactool compare --json arp-old.txt arp-new.txt`
+
+// Long help text for the compare command
+const compareLong = `Compare the MAC addresses in two inputs
+
+The MAC addresses found in the old and the new input are compared
+by value, regardless of notation and case, and printed in lower
+case with a mark of the set they are in:
+
+  -  removed, only found in the old input
+  +  added, only found in the new input
+  =  common, found in both inputs
+
+Either input may be - to read standard input. Use --only to print
+some of the sets, such as the difference (--only removed,added)
+or the intersection (--only common) of the inputs.`
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:          "compare OLD NEW",
+	Short:        "Compare the MAC addresses in two inputs",
+	Long:         compareLong,
+	Example:      compareExample,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Standard input can only be read once
+		if args[0] == "-" && args[1] == "-" {
+			return errCompareStdinTwice
+		}
+
+		// Open the OUI database if the vendors are to be printed
+		var ouiCsvFile io.Reader
+		if viper.GetBool("compare.vendor") {
+			// Get the OUI database file, shared with the lookup command
+			csv := viper.GetString("compare.oui-file")
+			if csv == "" {
+				csv = viper.GetString("lookup.oui-file")
+			}
+
+			// Check if the CSV file exists and download it if it doesn't
+			oui.UpdateDatabase(csv)
+
+			// Open the CSV file
+			file, err := os.Open(csv)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			ouiCsvFile = file
+		}
+
+		// Open the old and new inputs
+		oldInput := openCompareInput(args[0])
+		defer oldInput.Close()
+		newInput := openCompareInput(args[1])
+		defer newInput.Close()
+
+		// Determine the output file using Viper
+		outputFile := viper.GetString("compare.output-file")
+		append := viper.GetBool("compare.append")
+
+		// Get the output stream
+		outStream, err := utils.GetOutputStream(outputFile, append)
+		if err != nil {
+			return err
+		}
+		defer outStream.Close()
+
+		// Print the configuration debug if the --debug flag is set
+		if viper.GetBool("debug") {
+			debug.PrintConfigDebug()
+		}
+
+		// Compare the MAC addresses of the inputs
+		return compareAction(outStream, ouiCsvFile, oldInput, newInput)
+	},
+}
+
+func init() {
+	// Add the compare command to the root command
+	rootCmd.AddCommand(compareCmd)
+
+	// Add flag for the sets to print, which may be repeated
+	compareCmd.Flags().StringSlice("only", nil, "print only these sets: removed, added, common (may be repeated)")
+	viper.BindPFlag("compare.only", compareCmd.Flags().Lookup("only"))
+
+	// Set to the value of the --vendor flag if set
+	compareCmd.Flags().Bool("vendor", false, "print the vendors of the MAC addresses")
+	viper.BindPFlag("compare.vendor", compareCmd.Flags().Lookup("vendor"))
+
+	// Set to the value of the --oui-file flag if set
+	compareCmd.Flags().StringP("oui-file", "O", "", "path to OUI CSV file (default is the OUI file of the lookup command)")
+	viper.BindPFlag("compare.oui-file", compareCmd.Flags().Lookup("oui-file"))
+
+	// Set to the value of the --json flag if set
+	compareCmd.Flags().BoolP("json", "j", false, "write output in JSON format")
+	viper.BindPFlag("compare.json", compareCmd.Flags().Lookup("json"))
+
+	// Set to the value of the --loose flag if set
+	compareCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("compare.loose", compareCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	compareCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("compare.accept", compareCmd.Flags().Lookup("accept"))
+
+	// Add flag for output file path
+	compareCmd.Flags().StringP("output-file", "o", "", "write output to file")
+	viper.BindPFlag("compare.output-file", compareCmd.Flags().Lookup("output-file"))
+
+	// Set to the value of the --append flag if set
+	compareCmd.Flags().BoolP("append", "a", false, "append when writing to file with --output-file")
+	viper.BindPFlag("compare.append", compareCmd.Flags().Lookup("append"))
+}
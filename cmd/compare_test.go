@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestCompareAction tests the compareAction function
+func TestCompareAction(t *testing.T) {
+	// Create a test CSV database, in memory
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00000C,"Cisco Systems, Inc",170 WEST TASMAN DRIVE SAN JOSE CA US 95134`
+
+	oldInput := "Gi0/1 0000.0c00.0002\nGi0/2 0011.2233.4455\nGi0/3 00:00:0C:00:00:01"
+	newInput := "00-00-0C-00-00-01 Gi0/3\n00:00:0c:00:00:02 Gi0/1\n00:00:0c:00:00:03 Gi0/4\n52:54:00:12:34:56"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		only     []string
+		vendor   bool
+		json     bool
+		expected string
+		err      bool
+	}{
+		{
+			name: "AllSets",
+			expected: "- 00:11:22:33:44:55\n" +
+				"+ 00:00:0c:00:00:03\n" +
+				"+ 52:54:00:12:34:56\n" +
+				"= 00:00:0c:00:00:01\n" +
+				"= 00:00:0c:00:00:02\n",
+		},
+		{
+			name:   "DifferenceWithVendor",
+			only:   []string{"removed", "added"},
+			vendor: true,
+			expected: "- 00:11:22:33:44:55\n" +
+				"+ 00:00:0c:00:00:03 (Cisco Systems, Inc)\n" +
+				"+ 52:54:00:12:34:56 (QEMU/KVM)\n",
+		},
+		{
+			name:     "CommonJSON",
+			only:     []string{"common"},
+			json:     true,
+			expected: `{"common":[{"mac":"00:00:0c:00:00:01"},{"mac":"00:00:0c:00:00:02"}]}` + "\n",
+		},
+		{
+			name:     "EmptySetJSON",
+			only:     []string{"removed"},
+			vendor:   true,
+			json:     true,
+			expected: `{"removed":[{"mac":"00:11:22:33:44:55"}]}` + "\n",
+		},
+		{
+			name: "InvalidSet",
+			only: []string{"union"},
+			err:  true,
+		},
+	}
+
+	defer viper.Set("compare.only", nil)
+	defer viper.Set("compare.json", false)
+
+	// Run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Set("compare.only", tc.only)
+			viper.Set("compare.json", tc.json)
+
+			// Find the vendors in the test database if requested
+			var ouiCsvFile io.Reader
+			if tc.vendor {
+				ouiCsvFile = strings.NewReader(csvData)
+			}
+
+			// Call the function to test
+			var output bytes.Buffer
+			err := compareAction(&output, ouiCsvFile, newStringInput(oldInput), newStringInput(newInput))
+			if tc.err {
+				if err == nil {
+					t.Errorf("compareAction() returned no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("compareAction() returned error: %v", err)
+			}
+
+			// Check the output
+			if output.String() != tc.expected {
+				t.Errorf("compareAction() output =\n%s\nwant\n%s", output.String(), tc.expected)
+			}
+		})
+	}
+}
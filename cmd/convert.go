@@ -0,0 +1,299 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/debug"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/bitcanon/mactool/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// errNoConversion is returned when the convert command is run without
+// any of the flags that select the conversion
+var errNoConversion = errors.New("no conversion given; use --from, --to or --bit-reverse")
+
+// errNothingConverted is returned when none of the words of the input
+// could be read in the representation given by --from
+var errNothingConverted = errors.New("nothing converted; no words of the input are in the --from representation")
+
+// Parsers of the MAC address representations read by --from
+var convertParsers = map[string]func(string) (string, error){
+	"decimal":   mac.ParseDecimal,
+	"integer":   mac.ParseInteger,
+	"integer64": mac.ParseInteger64,
+	"binary":    mac.ParseBinary,
+	"multicast": mac.MulticastMacFromIP,
+}
+
+// Formatters of the MAC address representations written by --to
+var convertFormatters = map[string]func(string) (string, error){
+	"decimal":   mac.FormatDecimal,
+	"integer":   mac.FormatInteger,
+	"binary":    mac.FormatBinary,
+	"multicast": formatMulticastGroups,
+}
+
+// formatMulticastGroups returns the IPv4 or IPv6 multicast groups that map
+// to the MAC address, separated by spaces
+func formatMulticastGroups(macAddress string) (string, error) {
+	groups, err := mac.MulticastIPsFromMac(macAddress)
+	if err != nil {
+		return "", err
+	}
+	text := make([]string, len(groups))
+	for i, group := range groups {
+		text[i] = group.String()
+	}
+	return strings.Join(text, " "), nil
+}
+
+// convertAction finds the MAC addresses in the input, line by line, and
+// prints each of them converted as selected by the flags, one per line.
+// With --from, each word of the input is read in that representation
+// instead. The words that are not in it are skipped, unless they are given
+// as arguments, and an error is returned if every word was skipped.
+func convertAction(out io.Writer, input cli.Input) error {
+	from := viper.GetString("convert.from")
+	to := viper.GetString("convert.to")
+	bitReverse := viper.GetBool("convert.bit-reverse")
+	if from == "" && to == "" && !bitReverse {
+		return errNoConversion
+	}
+
+	// Select the representation to read
+	parse, ok := convertParsers[from]
+	if from != "" && !ok {
+		return fmt.Errorf("invalid --from %q; must be decimal, integer, integer64, binary or multicast", from)
+	}
+
+	// Select the representation to write
+	format, ok := convertFormatters[to]
+	if to != "" && !ok {
+		return fmt.Errorf("invalid --to %q; must be decimal, integer, binary or multicast", to)
+	}
+
+	// Buffer the output since it is written one address at a time
+	w := bufio.NewWriter(out)
+
+	// Prefix each result with the file name and line number if requested
+	withFilename := viper.GetBool("convert.with-filename")
+
+	// Find the MAC addresses with the scanner configured by the flags
+	scanner, err := newScanner("convert")
+	if err != nil {
+		return err
+	}
+
+	// Count the words read by --from and the ones skipped
+	converted, skipped := 0, 0
+
+	// Process the input one line at a time
+	err = input.Scan(func(line cli.Line) error {
+		// Find the MAC addresses in the line, or read the words of
+		// the line in the representation given by --from
+		var results []result
+		if parse != nil {
+			for _, word := range strings.Fields(line.Text) {
+				address, err := parse(word)
+				if err != nil {
+					// Arguments are typed by the user, so a typo is an error
+					if line.Source == cli.ArgumentsName {
+						return err
+					}
+					skipped++
+					continue
+				}
+				converted++
+				results = append(results, result{source: line.Source, number: line.Number, address: address})
+			}
+		} else {
+			results = newResults(line, scanner.FindAll(line.Text))
+		}
+
+		for _, r := range results {
+			// Convert the MAC address
+			converted := r.address
+			if bitReverse {
+				if converted, err = mac.BitReverse(converted); err != nil {
+					return fmt.Errorf("%s: %w", r.address, err)
+				}
+			}
+			if format != nil {
+				// Skip the unicast MAC addresses in the input when
+				// listing the groups of multicast MAC addresses
+				if converted, err = format(converted); errors.Is(err, mac.ErrNotMulticastMac) {
+					continue
+				} else if err != nil {
+					return fmt.Errorf("%s: %w", r.address, err)
+				}
+			}
+
+			// Print the converted MAC address to the output writer
+			fmt.Fprint(w, r.prefix(withFilename, false))
+			fmt.Fprintln(w, converted)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write any buffered output to the output writer
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// Fail if words were read by --from, but none of them were converted
+	if converted == 0 && skipped > 0 {
+		return fmt.Errorf("%w (%d skipped)", errNothingConverted, skipped)
+	}
+	return nil
+}
+
+// Example help text for the convert command
+const convertExample = `  mactool convert --bit-reverse 00:00:5e:00:53:01
+  mactool convert --bit-reverse -H -i token-ring.log
+  mactool convert --to integer 00:00:5e:00:53:01
+  mactool convert --from integer 1577079553
+  mactool convert --from multicast 239.1.1.1 ff02::fb
+  mactool convert --to multicast 01:00:5e:01:01:01
+  snmpwalk -v2c -c public switch dot1dTpFdbAddress | mactool convert --accept decimal --to binary
+
+Interactive mode:
+  mactool convert --bit-reverse`
+
+// Long help text for the convert command
+const convertLong = `Convert MAC addresses between representations
+
+The MAC addresses are found in the input like the extract command
+does, and each of them is printed converted, one per line.
+
+With --bit-reverse, the order of the bits in each octet is reversed,
+converting between the canonical form and the non-canonical form
+used by Token Ring, FDDI and some wireless tools. The notation of
+the MAC address is kept.
+
+With --to, the MAC addresses are written as dotted decimal
+(0.0.94.0.83.1), an unsigned integer (1577079553) or binary digits.
+With --from, each word of the input is read as dotted decimal, an
+unsigned integer or binary digits instead. The words of files and
+piped input that are not in that representation are skipped, and
+the command fails if none of them are. Each argument must be in it.
+
+Integers are read as EUI-48 addresses, and integers of more than 48
+bits are rejected. Use --from integer64 to read EUI-64 addresses.
+
+With --from multicast, IPv4 and IPv6 multicast groups are mapped
+to their MAC addresses (239.1.1.1 is 01:00:5e:01:01:01). With
+--to multicast, the groups that map to each MAC address are listed:
+the 32 IPv4 groups that share the MAC address, or the link-local
+IPv6 group. MAC addresses that are not multicast are skipped.`
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:          "convert [input]",
+	Short:        "Convert MAC addresses between representations",
+	Long:         convertLong,
+	Example:      convertExample,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open the input from file, pipe, arguments or interactive mode
+		input, err := openInput("convert", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		// Determine the output file using Viper
+		outputFile := viper.GetString("convert.output-file")
+		append := viper.GetBool("convert.append")
+
+		// Get the output stream
+		outStream, err := utils.GetOutputStream(outputFile, append)
+		if err != nil {
+			return err
+		}
+		defer outStream.Close()
+
+		// Print the configuration debug if the --debug flag is set
+		if viper.GetBool("debug") {
+			debug.PrintConfigDebug()
+		}
+
+		// Convert the MAC addresses in the input
+		return convertAction(outStream, input)
+	},
+}
+
+func init() {
+	// Add the convert command to the root command
+	rootCmd.AddCommand(convertCmd)
+
+	// Add flag for the representation to read
+	convertCmd.Flags().String("from", "", "read each word of the input as decimal, integer, integer64, binary or multicast (IP group)")
+	viper.BindPFlag("convert.from", convertCmd.Flags().Lookup("from"))
+
+	// Add flag for the representation to write
+	convertCmd.Flags().String("to", "", "write the MAC addresses as decimal, integer, binary or multicast (IP groups)")
+	viper.BindPFlag("convert.to", convertCmd.Flags().Lookup("to"))
+
+	// Set to the value of the --bit-reverse flag if set
+	convertCmd.Flags().BoolP("bit-reverse", "b", false, "reverse the bit order of each octet (canonical <-> non-canonical)")
+	viper.BindPFlag("convert.bit-reverse", convertCmd.Flags().Lookup("bit-reverse"))
+
+	// Add flag for input file paths, which may be repeated
+	convertCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
+	viper.BindPFlag("convert.input-file", convertCmd.Flags().Lookup("input-file"))
+
+	// Set to the value of the --recursive flag if set
+	convertCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("convert.recursive", convertCmd.Flags().Lookup("recursive"))
+
+	// Set to the value of the --with-filename flag if set
+	convertCmd.Flags().BoolP("with-filename", "H", false, "prefix each result with the file name and line number")
+	viper.BindPFlag("convert.with-filename", convertCmd.Flags().Lookup("with-filename"))
+
+	// Set to the value of the --loose flag if set
+	convertCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("convert.loose", convertCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	convertCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("convert.accept", convertCmd.Flags().Lookup("accept"))
+
+	// Add flag for output file path
+	convertCmd.Flags().StringP("output-file", "o", "", "write output to file")
+	viper.BindPFlag("convert.output-file", convertCmd.Flags().Lookup("output-file"))
+
+	// Set to the value of the --append flag if set
+	convertCmd.Flags().BoolP("append", "a", false, "append when writing to file with --output-file")
+	viper.BindPFlag("convert.append", convertCmd.Flags().Lookup("append"))
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/spf13/viper"
+)
+
+// TestConvertAction tests the convertAction function
+func TestConvertAction(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name       string
+		input      string
+		stream     bool
+		from       string
+		to         string
+		bitReverse bool
+		accept     []string
+		expected   string
+		err        error
+	}{
+		{
+			name:       "BitReverse",
+			input:      "canonical 00:00:5e:00:53:01, token ring 0000.7A00.CA80\nnone here",
+			bitReverse: true,
+			expected:   "00:00:7a:00:ca:80\n0000.5E00.5301\n",
+		},
+		{
+			name:     "ToInteger",
+			input:    "00:00:5e:00:53:01 0000.5E00.5302",
+			to:       "integer",
+			expected: "1577079553\n1577079554\n",
+		},
+		{
+			name:       "ToDecimalBitReversed",
+			input:      "00:00:7a:00:ca:80",
+			to:         "decimal",
+			bitReverse: true,
+			expected:   "0.0.94.0.83.1\n",
+		},
+		{
+			name:     "FromDecimalOid",
+			input:    "SNMPv2-SMI::mib-2.17.4.3.1.1.0.0.94.0.83.1 = INTEGER: 5",
+			accept:   []string{"decimal"},
+			to:       "integer",
+			expected: "1577079553\n",
+		},
+		{
+			name:     "FromIntegerToBinary",
+			input:    "ifIndex 5 mac 1577079553",
+			stream:   true,
+			from:     "integer",
+			to:       "binary",
+			expected: "000000000000000000000000000000000000000000000101\n000000000000000001011110000000000101001100000001\n",
+		},
+		{
+			name:  "FromIntegerMoreThan48Bits",
+			input: "281474976710656",
+			from:  "integer",
+			err:   mac.ErrInvalidMacAddress,
+		},
+		{
+			name:     "FromInteger64",
+			input:    "281474976710656",
+			from:     "integer64",
+			expected: "00:01:00:00:00:00:00:00\n",
+		},
+		{
+			name:     "FromMulticast",
+			input:    "igmp group 239.1.1.1 from 192.0.2.1, mld group ff02::fb",
+			stream:   true,
+			from:     "multicast",
+			expected: "01:00:5e:01:01:01\n33:33:00:00:00:fb\n",
+		},
+		{
+			name:  "FromMulticastArgumentTypo",
+			input: "239.1.1.1 239.1.1",
+			from:  "multicast",
+			err:   mac.ErrNotMulticastIP,
+		},
+		{
+			name:   "FromMulticastNothingConverted",
+			input:  "igmp group 239.1.1\nmld group fe80::1",
+			stream: true,
+			from:   "multicast",
+			err:    errNothingConverted,
+		},
+		{
+			name:  "ToMulticast",
+			input: "01:00:5e:7f:ff:fa 3333.FF12.3456",
+			to:    "multicast",
+			expected: "224.127.255.250 224.255.255.250 225.127.255.250 225.255.255.250 " +
+				"226.127.255.250 226.255.255.250 227.127.255.250 227.255.255.250 " +
+				"228.127.255.250 228.255.255.250 229.127.255.250 229.255.255.250 " +
+				"230.127.255.250 230.255.255.250 231.127.255.250 231.255.255.250 " +
+				"232.127.255.250 232.255.255.250 233.127.255.250 233.255.255.250 " +
+				"234.127.255.250 234.255.255.250 235.127.255.250 235.255.255.250 " +
+				"236.127.255.250 236.255.255.250 237.127.255.250 237.255.255.250 " +
+				"238.127.255.250 238.255.255.250 239.127.255.250 239.255.255.250\n" +
+				"ff02::1:ff12:3456\n",
+		},
+		{
+			name:     "ToMulticastSkipsUnicast",
+			input:    "arp 00:00:5e:00:53:01 mld 33:33:00:00:00:fb\narp 00:00:5e:00:53:02",
+			to:       "multicast",
+			expected: "ff02::fb\n",
+		},
+		{
+			name:  "NoConversion",
+			input: "00:00:5e:00:53:01",
+			err:   errNoConversion,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("convert.from", test.from)
+			viper.Set("convert.to", test.to)
+			viper.Set("convert.bit-reverse", test.bitReverse)
+			viper.Set("convert.accept", test.accept)
+			defer viper.Set("convert.from", "")
+			defer viper.Set("convert.to", "")
+			defer viper.Set("convert.bit-reverse", false)
+			defer viper.Set("convert.accept", nil)
+
+			// Call the function to test
+			var output bytes.Buffer
+			input := newStringInput(test.input)
+			if test.stream {
+				input = cli.NewReaderInput(cli.StdinName, io.NopCloser(strings.NewReader(test.input)))
+			}
+			err := convertAction(&output, input)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
@@ -22,44 +22,123 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"io"
-	"os"
-	"sort"
-	"strings"
 
 	"github.com/bitcanon/mactool/cli"
 	"github.com/bitcanon/mactool/debug"
-	"github.com/bitcanon/mactool/mac"
 	"github.com/bitcanon/mactool/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// extractAction extracts MAC addresses from the input string
-func extractAction(out io.Writer, s string) error {
-	// Extract MAC addresses from string
-	macs, err := mac.FindAllMacAddresses(s)
+// extractAction extracts MAC addresses from the input, line by line,
+// and prints them to the output writer as they are found. If the
+// output is to be sorted, all addresses are collected before printing.
+func extractAction(out io.Writer, input cli.Input) error {
+	// Buffer the output since it is written one address at a time
+	w := bufio.NewWriter(out)
+
+	// Sorting and counting require all MAC addresses to be known before printing
+	sortAsc := viper.GetBool("extract.sort-asc")
+	sortDesc := viper.GetBool("extract.sort-desc")
+	unique := viper.GetBool("extract.unique")
+	count := viper.GetBool("extract.count")
+	collect := sortAsc || sortDesc || unique || count
+	var results []result
+
+	// When following a file, the output is written as soon as the
+	// addresses are found, optionally only the first time they are seen
+	follow := viper.GetBool("extract.follow")
+	var seen seenAddresses
+	if viper.GetBool("extract.new-only") {
+		seen = seenAddresses{}
+	}
+
+	// Prefix each result with the file name, line number
+	// and column of the MAC address if requested
+	withFilename := viper.GetBool("extract.with-filename")
+	withLineNumbers := viper.GetBool("extract.with-line-numbers")
+
+	// Find the MAC addresses with the scanner configured by the flags
+	scanner, err := newScanner("extract")
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 
-	// Sort MAC addresses in ascending or descending order
-	if viper.GetBool("extract.sort-asc") {
-		sort.Strings(macs)
-	} else if viper.GetBool("extract.sort-desc") {
-		sort.Sort(sort.Reverse(sort.StringSlice(macs)))
+	// Only keep the MAC addresses in the prefixes of the --match-prefix flag
+	prefixes, err := newPrefixFilter("extract")
+	if err != nil {
+		return err
+	}
+
+	// Process the input one line at a time
+	err = input.Scan(func(line cli.Line) error {
+		// Extract MAC addresses from the line
+		found := scanner.FindAll(line.Text)
+		if prefixes != nil {
+			found = prefixes.filter(found)
+		}
+
+		// Skip the MAC addresses that have already been seen
+		if seen != nil {
+			found = seen.filterNew(found)
+		}
+
+		// Save the MAC addresses for later if the output is sorted or counted
+		if collect {
+			results = append(results, newResults(line, found)...)
+			return nil
+		}
+
+		// Print MAC addresses found in the line
+		// to the output writer
+		printAddresses(w, newResults(line, found), withFilename, withLineNumbers, false)
+
+		// Write the output right away when following a file
+		if follow {
+			return w.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// Print MAC addresses found in the input string
-	// to the output writer
-	for _, mac := range macs {
-		fmt.Fprintln(out, mac)
+	// Keep each MAC address once, counting the times it was found
+	if unique || count {
+		results = uniqueResults(results)
 	}
 
-	// No errors occurred
-	return nil
+	// Sort MAC addresses in ascending or descending order
+	if sortAsc {
+		sortResults(results, false)
+	} else if sortDesc {
+		sortResults(results, true)
+	}
+
+	// Print the sorted MAC addresses to the output writer
+	printAddresses(w, results, withFilename, withLineNumbers, count)
+
+	// Write any buffered output to the output writer
+	return w.Flush()
+}
+
+// printAddresses prints the MAC addresses to the output writer, one per
+// line, prefixed with the file name and line number if withFilename is set,
+// and with the line number and column if withLineNumbers is set. If
+// withCount is set, each MAC address is prefixed with the number of times
+// it was found instead, and followed by the first and last lines.
+func printAddresses(out io.Writer, results []result, withFilename bool, withLineNumbers bool, withCount bool) {
+	for _, r := range results {
+		if withCount {
+			fmt.Fprintf(out, "%7d %s %s\n", r.count, r.address, r.seenOn(withFilename))
+			continue
+		}
+		fmt.Fprint(out, r.prefix(withFilename, withLineNumbers))
+		fmt.Fprintln(out, r.address)
+	}
 }
 
 // Example help text for the extract command
@@ -67,6 +146,11 @@ const extractExample = `  mactool extract 0000.5e00.5301 00:00:5e:00:53:01 0000-
   mactool extract First address 0000.5E00.5301, second address 00:00:5e:00:53:01, etc.
   cat macs.txt | mactool extract
   ipconfig /all | mactool extract
+  mactool extract --follow --new-only -i /var/log/syslog
+  mactool extract -H -r -i backups/ -i "logs/*.log"
+  mactool extract -H -N -i switch.cfg
+  mactool extract --count -s -i /var/log/dhcpd.log
+  getmac | mactool extract --accept bare
 
 Interactive mode:
   mactool extract
@@ -78,7 +162,9 @@ process output from a network device containing MAC addresses.`
 const extractLong = `Extract MAC addresses from the input string
 
 The command takes input in the form of command line arguments,
-standard input (piped data) or interactive input.`
+standard input (piped data), one or more files or interactive input.
+Compressed files (gzip, bzip2, zstd) and archives (zip, tar) are
+detected automatically and read without unpacking them first.`
 
 // extractCmd represents the extract command
 var extractCmd = &cobra.Command{
@@ -87,37 +173,13 @@ var extractCmd = &cobra.Command{
 	Long:    extractLong,
 	Example: extractExample,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Input string to hold the processed input
-		var input string
-		var err error
-
-		// Check if data is being piped, read from file or redirected to stdin
-		if viper.GetString("extract.input-file") != "" {
-			// Read input from file
-			input, err = cli.ProcessFile(viper.GetString("extract.input-file"))
-			if err != nil {
-				return err
-			}
-		} else if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) == 0 {
-			// Process data from pipe or redirection (stdin)
-			input, err = cli.ProcessStdin()
-			if err != nil {
-				return err
-			}
-		} else {
-			if len(args) == 0 {
-				// If there are no command line arguments,
-				// enter interactive mode and read user input
-				input, err = cli.ProcessInteractiveInput()
-				if err != nil {
-					return err
-				}
-			} else {
-				// If there are command line arguments, join them
-				// into a single string and use that as user input
-				input = strings.Join(args, " ")
-			}
+		// Open the input from file, pipe, arguments or interactive mode,
+		// or follow the input file if the --follow flag is set
+		input, err := openInput("extract", args)
+		if err != nil {
+			return err
 		}
+		defer input.Close()
 
 		// Determine the output file using Viper
 		outputFile := viper.GetString("extract.output-file")
@@ -135,7 +197,7 @@ var extractCmd = &cobra.Command{
 			debug.PrintConfigDebug()
 		}
 
-		// Extract MAC addresses from string and
+		// Extract MAC addresses from the input and
 		// print them to standard output
 		return extractAction(outStream, input)
 	},
@@ -153,10 +215,50 @@ func init() {
 	extractCmd.Flags().BoolP("sort-desc", "S", false, "sort output in descending order")
 	viper.BindPFlag("extract.sort-desc", extractCmd.Flags().Lookup("sort-desc"))
 
-	// Add flag for input file path
-	extractCmd.Flags().StringP("input-file", "i", "", "read input from file")
+	// Set to the value of the --unique flag if set
+	extractCmd.Flags().BoolP("unique", "U", false, uniqueUsage)
+	viper.BindPFlag("extract.unique", extractCmd.Flags().Lookup("unique"))
+
+	// Set to the value of the --count flag if set
+	extractCmd.Flags().BoolP("count", "C", false, countUsage)
+	viper.BindPFlag("extract.count", extractCmd.Flags().Lookup("count"))
+
+	// Add flag for input file paths, which may be repeated
+	extractCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
 	viper.BindPFlag("extract.input-file", extractCmd.Flags().Lookup("input-file"))
 
+	// Set to the value of the --recursive flag if set
+	extractCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("extract.recursive", extractCmd.Flags().Lookup("recursive"))
+
+	// Set to the value of the --with-filename flag if set
+	extractCmd.Flags().BoolP("with-filename", "H", false, "prefix each result with the file name and line number")
+	viper.BindPFlag("extract.with-filename", extractCmd.Flags().Lookup("with-filename"))
+
+	// Set to the value of the --loose flag if set
+	extractCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("extract.loose", extractCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	extractCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("extract.accept", extractCmd.Flags().Lookup("accept"))
+
+	// Add flag for the prefixes to match, which may be repeated
+	extractCmd.Flags().StringSlice("match-prefix", nil, matchPrefixUsage)
+	viper.BindPFlag("extract.match-prefix", extractCmd.Flags().Lookup("match-prefix"))
+
+	// Set to the value of the --with-line-numbers flag if set
+	extractCmd.Flags().BoolP("with-line-numbers", "N", false, "prefix each result with the line number and column it was found at")
+	viper.BindPFlag("extract.with-line-numbers", extractCmd.Flags().Lookup("with-line-numbers"))
+
+	// Set to the value of the --follow flag if set
+	extractCmd.Flags().BoolP("follow", "f", false, "keep reading lines appended to the input file, like tail -F")
+	viper.BindPFlag("extract.follow", extractCmd.Flags().Lookup("follow"))
+
+	// Set to the value of the --new-only flag if set
+	extractCmd.Flags().BoolP("new-only", "n", false, "with --follow, print each MAC address only the first time it is seen")
+	viper.BindPFlag("extract.new-only", extractCmd.Flags().Lookup("new-only"))
+
 	// Add flag for output file path
 	extractCmd.Flags().StringP("output-file", "o", "", "write output to file")
 	viper.BindPFlag("extract.output-file", extractCmd.Flags().Lookup("output-file"))
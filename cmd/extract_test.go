@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/bitcanon/mactool/cli"
 	"github.com/bitcanon/mactool/utils"
 	"github.com/spf13/viper"
 )
@@ -51,6 +55,11 @@ func TestExtractAction(t *testing.T) {
 			input:    "",
 			expected: "",
 		},
+		{
+			name:     "InputOrderAcrossNotations",
+			input:    "Port 1 0000.5e00.5301, port 2 00:00:5e:00:53:02, port 3 00005e-005303",
+			expected: "0000.5e00.5301\n00:00:5e:00:53:02\n00005e-005303\n",
+		},
 		{
 			name:     "SingleLineInputWithSortAsc",
 			input:    "First line of input with one MAC address 00:00:5e:00:53:01 in it.",
@@ -89,6 +98,18 @@ func TestExtractAction(t *testing.T) {
 			sortAsc:  true,
 			expected: "11:11:11:11:11:11\n22:22:22:22:22:22\n33:33:33:33:33:33\n44:44:44:44:44:44\n55:55:55:55:55:55\n",
 		},
+		{
+			name:     "MixedNotationsWithSortAsc",
+			input:    `0011.2233.4456 00:11:22:ff:fe:33:44:55 00:11:22:33:44:55 00-1A-00-00-00-00 00:0a:00:00:00:00 0011.2233.4455`,
+			sortAsc:  true,
+			expected: "00:0a:00:00:00:00\n00:11:22:33:44:55\n0011.2233.4455\n0011.2233.4456\n00-1A-00-00-00-00\n00:11:22:ff:fe:33:44:55\n",
+		},
+		{
+			name:     "MixedNotationsWithSortDesc",
+			input:    `0011.2233.4456 00:11:22:ff:fe:33:44:55 00:11:22:33:44:55 00-1A-00-00-00-00 00:0a:00:00:00:00 0011.2233.4455`,
+			sortDesc: true,
+			expected: "00:11:22:ff:fe:33:44:55\n00-1A-00-00-00-00\n0011.2233.4456\n00:11:22:33:44:55\n0011.2233.4455\n00:0a:00:00:00:00\n",
+		},
 	}
 
 	// Loop through the test cases and run each test
@@ -102,7 +123,7 @@ func TestExtractAction(t *testing.T) {
 			viper.Set("extract.sort-desc", test.sortDesc)
 
 			// Call the function to test
-			err := extractAction(&output, test.input)
+			err := extractAction(&output, newStringInput(test.input))
 
 			// Check for errors
 			if err != nil {
@@ -217,7 +238,7 @@ func TestExtractActionOutput(t *testing.T) {
 			defer outStream.Close()
 
 			// Call the function to test
-			err = extractAction(outStream, test.input)
+			err = extractAction(outStream, newStringInput(test.input))
 			if err != nil {
 				t.Errorf("error returned from extractAction(): %v", err)
 				return
@@ -237,3 +258,317 @@ func TestExtractActionOutput(t *testing.T) {
 		})
 	}
 }
+
+// benchmarkInputSize is the size in bytes of the generated
+// input used to benchmark processing of huge inputs
+const benchmarkInputSize = 1 << 30
+
+// generatedInput is an io.Reader producing syslog-like lines containing
+// MAC addresses, until the requested number of bytes has been produced.
+// The input is generated on the fly, so the full input is never in memory.
+type generatedInput struct {
+	remaining int
+	lineCount int
+	pending   []byte
+}
+
+// newGeneratedInput returns a reader producing size bytes of input
+func newGeneratedInput(size int) *generatedInput {
+	return &generatedInput{remaining: size}
+}
+
+// Read implements the io.Reader interface
+func (g *generatedInput) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) && g.remaining > 0 {
+		// Generate a new line when the previous one has been consumed
+		if len(g.pending) == 0 {
+			g.lineCount++
+			g.pending = []byte(fmt.Sprintf(
+				"Oct 18 12:00:00 relay dhcpd[%d]: DHCPACK on 10.0.%d.%d to 00:00:5e:%02x:%02x:%02x via eth0\n",
+				g.lineCount, g.lineCount%256, g.lineCount/256%256,
+				g.lineCount>>16&0xff, g.lineCount>>8&0xff, g.lineCount&0xff))
+		}
+
+		// Copy as much of the pending line as fits in p
+		c := copy(p[n:], g.pending)
+		if c > g.remaining {
+			c = g.remaining
+		}
+		g.pending = g.pending[c:]
+		g.remaining -= c
+		n += c
+	}
+
+	// Signal the end of the input when all bytes have been produced
+	if n == 0 && g.remaining == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// BenchmarkExtractAction benchmarks the extractAction function
+// on a generated input of benchmarkInputSize bytes
+func BenchmarkExtractAction(b *testing.B) {
+	// Disable sorting so the output is streamed
+	viper.Set("extract.sort-asc", false)
+	viper.Set("extract.sort-desc", false)
+
+	b.ReportAllocs()
+	b.SetBytes(benchmarkInputSize)
+	for i := 0; i < b.N; i++ {
+		err := extractAction(io.Discard, cli.NewReaderInput("generated", io.NopCloser(newGeneratedInput(benchmarkInputSize))))
+		if err != nil {
+			b.Fatalf("error returned from extractAction(): %v", err)
+		}
+	}
+}
+
+// TestExtractActionWithFilename tests the extractAction function
+// with the --with-filename flag set, reading from multiple files
+func TestExtractActionWithFilename(t *testing.T) {
+	// Create two input files
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.log")
+	second := filepath.Join(dir, "second.log")
+	os.WriteFile(first, []byte("no address\nAddress 00:00:5e:00:53:02 found\n"), 0644)
+	os.WriteFile(second, []byte("00:00:5e:00:53:01 and 00-00-5E-00-53-03\n"), 0644)
+
+	// Setup test cases
+	testCases := []struct {
+		name            string
+		sortAsc         bool
+		withLineNumbers bool
+		expected        string
+	}{
+		{
+			name: "InputOrder",
+			expected: first + ":2:00:00:5e:00:53:02\n" +
+				second + ":1:00:00:5e:00:53:01\n" +
+				second + ":1:00-00-5E-00-53-03\n",
+		},
+		{
+			name:    "SortAsc",
+			sortAsc: true,
+			expected: second + ":1:00:00:5e:00:53:01\n" +
+				first + ":2:00:00:5e:00:53:02\n" +
+				second + ":1:00-00-5E-00-53-03\n",
+		},
+		{
+			name:            "WithLineNumbers",
+			withLineNumbers: true,
+			expected: first + ":2:9:00:00:5e:00:53:02\n" +
+				second + ":1:1:00:00:5e:00:53:01\n" +
+				second + ":1:23:00-00-5E-00-53-03\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("extract.sort-asc", test.sortAsc)
+			viper.Set("extract.sort-desc", false)
+			viper.Set("extract.with-filename", true)
+			viper.Set("extract.with-line-numbers", test.withLineNumbers)
+			defer viper.Set("extract.with-filename", false)
+			defer viper.Set("extract.with-line-numbers", false)
+
+			// Call the function to test
+			var output bytes.Buffer
+			err := extractAction(&output, cli.NewFileInput([]string{first, second}))
+			if err != nil {
+				t.Fatalf("error returned from extractAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
+
+// TestExtractActionMatchPrefix tests the extractAction function with
+// the MAC addresses filtered by the --match-prefix flag
+func TestExtractActionMatchPrefix(t *testing.T) {
+	input := "00:1a:2b:00:00:01 001A.2B00.0010 00-1a-2b-00-00-0f 00:00:5e:00:53:01"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		prefixes []string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "NoPrefix",
+			expected: "00:1a:2b:00:00:01\n001A.2B00.0010\n00-1a-2b-00-00-0f\n00:00:5e:00:53:01\n",
+		},
+		{
+			name:     "Cidr",
+			prefixes: []string{"00:1a:2b:00:00:00/44"},
+			expected: "00:1a:2b:00:00:01\n00-1a-2b-00-00-0f\n",
+		},
+		{
+			name:     "SeveralPrefixes",
+			prefixes: []string{"00:1A:2B:00:00:1*", "00:00:5e:*"},
+			expected: "001A.2B00.0010\n00:00:5e:00:53:01\n",
+		},
+		{
+			name:     "InvalidPrefix",
+			prefixes: []string{"00:1a:2b/24"},
+			err:      true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("extract.sort-asc", false)
+			viper.Set("extract.sort-desc", false)
+			viper.Set("extract.match-prefix", test.prefixes)
+			defer viper.Set("extract.match-prefix", nil)
+
+			// Call the function to test
+			var output bytes.Buffer
+			err := extractAction(&output, newStringInput(input))
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error returned from extractAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
+
+// TestExtractActionAccept tests the extractAction function
+// with extra notations accepted by the --accept flag
+func TestExtractActionAccept(t *testing.T) {
+	input := "getmac: 001A2B3C4D5E, ip: 00 1a 2b 3c 4d 5f, snmp: 0x001a2b3c4d60"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		accept   []string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "NoneAccepted",
+			expected: "",
+		},
+		{
+			name:     "AllAccepted",
+			accept:   []string{"bare", "space", "hex"},
+			expected: "001A2B3C4D5E\n00 1a 2b 3c 4d 5f\n0x001a2b3c4d60\n",
+		},
+		{
+			name:     "BareAccepted",
+			accept:   []string{"bare"},
+			expected: "001A2B3C4D5E\n",
+		},
+		{
+			name:   "InvalidNotation",
+			accept: []string{"dotted"},
+			err:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("extract.sort-asc", false)
+			viper.Set("extract.sort-desc", false)
+			viper.Set("extract.accept", test.accept)
+			defer viper.Set("extract.accept", nil)
+
+			// Call the function to test
+			var output bytes.Buffer
+			err := extractAction(&output, newStringInput(input))
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error returned from extractAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
+
+// TestExtractActionUnique tests the extractAction function with each
+// MAC address printed once by the --unique and --count flags
+func TestExtractActionUnique(t *testing.T) {
+	input := "0011.2233.4455 seen\n" +
+		"00:11:22:33:44:66 and 00:11:22:33:44:55\n" +
+		"nothing here\n" +
+		"00-11-22-33-44-55"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		unique   bool
+		count    bool
+		sortAsc  bool
+		expected string
+	}{
+		{
+			name:   "Unique",
+			unique: true,
+			expected: "0011.2233.4455\n" +
+				"00:11:22:33:44:66\n",
+		},
+		{
+			name:  "Count",
+			count: true,
+			expected: "      3 0011.2233.4455 first 1 last 4\n" +
+				"      1 00:11:22:33:44:66 first 2 last 2\n",
+		},
+		{
+			name:    "CountSortAsc",
+			count:   true,
+			sortAsc: true,
+			expected: "      3 0011.2233.4455 first 1 last 4\n" +
+				"      1 00:11:22:33:44:66 first 2 last 2\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("extract.sort-asc", test.sortAsc)
+			viper.Set("extract.sort-desc", false)
+			viper.Set("extract.unique", test.unique)
+			viper.Set("extract.count", test.count)
+			defer viper.Set("extract.unique", false)
+			defer viper.Set("extract.count", false)
+
+			// Call the function to test
+			var output bytes.Buffer
+			if err := extractAction(&output, newStringInput(input)); err != nil {
+				t.Fatalf("error returned from extractAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
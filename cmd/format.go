@@ -22,9 +22,12 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/bitcanon/mactool/cli"
@@ -35,6 +38,13 @@ import (
 	"github.com/spf13/viper"
 )
 
+// Errors returned when the in-place flags are used incorrectly
+var errInPlaceRequiresInputFile = errors.New("--in-place and --dry-run require input files (--input-file)")
+var errInPlaceWithFollow = errors.New("--in-place and --dry-run cannot be used with --follow")
+
+// errReverseRequiresTemplate is returned when reversing without a template
+var errReverseRequiresTemplate = errors.New("--reverse and --bit-reverse require --template")
+
 // createMacFormatFromFlags creates a MacFormat struct from the flags.
 func createMacFormatFromFlags(upper bool, lower bool, delimiter string, groupSize int) mac.MacFormat {
 	// Select character case based on flags
@@ -77,50 +87,359 @@ func createMacFormatFromFlags(upper bool, lower bool, delimiter string, groupSiz
 	}
 }
 
-// formatAction finds and formats MAC addresses in the input string.
+// createFormatterFromFlags creates the formatter used by the specified
+// command from its flags. A profile given with --profile, or a template or
+// preset given with --template, replaces the case, delimiter and group size
+// flags. This is the one place where the profiles are resolved, so that all
+// of the commands that format MAC addresses use them the same way.
+func createFormatterFromFlags(command string) (mac.Formatter, error) {
+	// Use the named profile from the config file
+	if profile := viper.GetString(command + ".profile"); profile != "" {
+		return loadProfile(profile)
+	}
+
+	template := viper.GetString(command + ".template")
+	reverse := viper.GetBool(command + ".reverse")
+	bitReverse := viper.GetBool(command + ".bit-reverse")
+
+	// Use the case, delimiter and group size flags without a template
+	if template == "" {
+		if reverse || bitReverse {
+			return nil, errReverseRequiresTemplate
+		}
+		return createMacFormatFromFlags(
+			viper.GetBool(command+".upper"),
+			viper.GetBool(command+".lower"),
+			viper.GetString(command+".delimiter"),
+			viper.GetInt(command+".group-size"),
+		), nil
+	}
+
+	// A template pattern has placeholders, otherwise it is the name of a preset
+	return parseTemplateOrPreset(template, reverse, bitReverse)
+}
+
+// lineFormatter finds the MAC addresses in lines of text using the
+// scanner and formats them according to the format
+type lineFormatter struct {
+	scanner mac.Scanner
+	format  mac.Formatter
+}
+
+// newLineFormatter returns a lineFormatter for the format, finding MAC
+// addresses with the scanner configured by the flags of the format command
+func newLineFormatter(format mac.Formatter) (lineFormatter, error) {
+	scanner, err := newScanner("format")
+	if err != nil {
+		return lineFormatter{}, err
+	}
+	return lineFormatter{scanner: scanner, format: format}, nil
+}
+
+// formatLine finds and formats the MAC addresses in the line. The MAC
+// addresses are formatted according to the provided format, inside the line.
+// Only the text of each MAC address found is replaced, so other occurrences
+// of the same text in the line are left as they are.
+func (f lineFormatter) formatLine(line string) (string, error) {
+	// Build the formatted line from the text between the MAC addresses
+	// and the formatted MAC addresses
+	var b strings.Builder
+	end := 0
+
+	// Loop through each MAC address found in the line
+	for _, m := range f.scanner.FindAll(line) {
+		// Format the MAC address
+		formattedMacAddress, err := f.format.Format(m.Address())
+		if err != nil {
+			return "", err
+		}
+		// Replace the MAC address with the formatted version
+		b.WriteString(line[end:m.Offset])
+		b.WriteString(formattedMacAddress)
+		end = m.End()
+	}
+
+	// Add the rest of the line after the last MAC address
+	b.WriteString(line[end:])
+
+	// Return the formatted line
+	return b.String(), nil
+}
+
+// formatAction finds and formats MAC addresses in the input, line by line.
 // The MAC addresses are formatted according to the provided format,
-// inside the input string, and printed to the output writer.
-func formatAction(out io.Writer, format mac.MacFormat, s string) error {
-	// If the input string is empty, return without doing anything
-	if len(s) == 0 {
-		return nil
+// inside each line, and the lines are printed to the output writer.
+func formatAction(out io.Writer, format mac.Formatter, input cli.Input) error {
+	// Find the MAC addresses with the scanner configured by the flags
+	formatter, err := newLineFormatter(format)
+	if err != nil {
+		return err
 	}
 
-	// Split the input string into lines
-	lines := strings.Split(s, "\n")
+	// Buffer the output since it is written one line at a time
+	w := bufio.NewWriter(out)
+
+	// When following a file, each line is written as soon as it is read
+	follow := viper.GetBool("format.follow")
+
+	// Prefix each line with the file name and line number if requested
+	withFilename := viper.GetBool("format.with-filename")
 
 	// Process each line separately
-	for i, line := range lines {
-		// Find all MAC addresses in the line
-		macs, err := mac.FindAllMacAddresses(line)
+	err = input.Scan(func(line cli.Line) error {
+		// Format the MAC addresses in the line
+		text, err := formatter.formatLine(line.Text)
 		if err != nil {
 			return err
 		}
 
-		// Loop through each MAC address found in the line
-		for _, m := range macs {
-			// Format the MAC address
-			formattedMacAddress, err := mac.FormatMacAddress(m, format)
-			if err != nil {
+		// Print the line to the output writer
+		if withFilename {
+			text = fmt.Sprintf("%s:%d:%s", line.Source, line.Number, text)
+		}
+		fmt.Fprintln(w, text)
+
+		// Write the output right away when following a file
+		if follow {
+			return w.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write any buffered output to the output writer
+	return w.Flush()
+}
+
+// formatInPlaceAction finds and formats MAC addresses in each of the files,
+// rewriting the files with the result. If backupSuffix is not empty, a copy
+// of each changed file is saved with the suffix appended to the file name.
+// If dryRun is set, the files are left unchanged and the changes that would
+// have been made are written to the output writer as a unified diff.
+func formatInPlaceAction(out io.Writer, format mac.Formatter, filenames []string, backupSuffix string, dryRun bool) error {
+	// Find the MAC addresses with the scanner configured by the flags
+	formatter, err := newLineFormatter(format)
+	if err != nil {
+		return err
+	}
+
+	for _, filename := range filenames {
+		// Compressed files and archives cannot be rewritten line by line
+		compressed, err := cli.IsCompressed(filename)
+		if err != nil {
+			return err
+		}
+		if compressed {
+			return fmt.Errorf("%s is compressed or an archive and cannot be rewritten in place", filename)
+		}
+
+		// Show the changes without rewriting the file
+		if dryRun {
+			if err := diffFormattedFile(out, formatter, filename); err != nil {
 				return err
 			}
-			// Replace the MAC address with the formatted version
-			lines[i] = strings.ReplaceAll(lines[i], m, formattedMacAddress)
+			continue
 		}
 
-		// Print the line to the output writer
-		fmt.Fprintln(out, lines[i])
+		// Rewrite the file with the formatted MAC addresses
+		if err := rewriteFormattedFile(formatter, filename, backupSuffix); err != nil {
+			return err
+		}
 	}
 
 	// No errors occurred
 	return nil
 }
 
+// inPlaceFiles returns the input files to rewrite in place. The backups
+// kept by earlier runs, with names ending in the backup suffix, are left
+// out, so that they are not formatted and backed up again.
+func inPlaceFiles(backupSuffix string) ([]string, error) {
+	// Expand the input files and directories
+	filenames, err := cli.ExpandFiles(
+		viper.GetStringSlice("format.input-file"),
+		viper.GetBool("format.recursive"),
+	)
+	if err != nil || backupSuffix == "" {
+		return filenames, err
+	}
+
+	// Leave out the backups
+	var targets []string
+	for _, filename := range filenames {
+		if !strings.HasSuffix(filename, backupSuffix) {
+			targets = append(targets, filename)
+		}
+	}
+	return targets, nil
+}
+
+// formatStream formats the MAC addresses in each line read from r and
+// writes the lines to w, keeping the original line endings, including a
+// missing line ending on the last line. If onLine is not nil, it is called
+// with each original and formatted line and the line ending. It returns true
+// if any line changed.
+func formatStream(w io.Writer, formatter lineFormatter, r io.Reader, onLine func(before, after, ending string)) (bool, error) {
+	reader := bufio.NewReader(r)
+	changed := false
+
+	for {
+		// Read the next line, including the line ending
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return false, readErr
+		}
+
+		if len(line) > 0 {
+			// Separate the line ending from the text of the line
+			text := strings.TrimRight(line, "\r\n")
+			ending := line[len(text):]
+
+			// Format the MAC addresses in the line
+			formatted, err := formatter.formatLine(text)
+			if err != nil {
+				return false, err
+			}
+			if formatted != text {
+				changed = true
+			}
+			if onLine != nil {
+				onLine(text, formatted, ending)
+			}
+
+			// Write the formatted line with the original line ending
+			if _, err := io.WriteString(w, formatted+ending); err != nil {
+				return false, err
+			}
+		}
+
+		// Stop at the end of the input
+		if readErr == io.EOF {
+			return changed, nil
+		}
+	}
+}
+
+// diffFormattedFile writes the changes that formatting the MAC
+// addresses in the file would make to the output writer as a unified diff
+func diffFormattedFile(out io.Writer, formatter lineFormatter, filename string) error {
+	// Open the file
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Collect the original and formatted lines of the file
+	var before, after []string
+	noFinalNewline := false
+	_, err = formatStream(io.Discard, formatter, file, func(b, a, ending string) {
+		before = append(before, b)
+		after = append(after, a)
+		noFinalNewline = ending == ""
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write the differences between the lines
+	return utils.WriteUnifiedDiff(out, filename, filename, before, after, noFinalNewline)
+}
+
+// rewriteFormattedFile formats the MAC addresses in the file and replaces the
+// file with the result. The result is written to a temporary file in the same
+// directory, which is then renamed to replace the original file, so the file
+// is never left partially written. Unchanged files are not rewritten.
+func rewriteFormattedFile(formatter lineFormatter, filename string, backupSuffix string) error {
+	// Open the file
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Get the permissions of the file to apply to the new file
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	// Create a temporary file next to the file, and make sure it is removed
+	// if anything goes wrong before it has replaced the original file
+	temp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	defer temp.Close()
+
+	// Write the formatted lines to the temporary file
+	w := bufio.NewWriter(temp)
+	changed, err := formatStream(w, formatter, file, nil)
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// Leave the file untouched if nothing changed
+	if !changed {
+		return nil
+	}
+
+	// Make sure the temporary file is complete on disk before replacing
+	if err := temp.Chmod(info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	// Keep a copy of the original file if a backup suffix is set
+	if backupSuffix != "" {
+		if err := backupFile(filename, filename+backupSuffix); err != nil {
+			return err
+		}
+	}
+
+	// Replace the original file with the formatted file
+	return os.Rename(temp.Name(), filename)
+}
+
+// backupFile saves a copy of the file as backup, replacing any previous
+// backup. A hard link is used if possible, otherwise the file is copied.
+func backupFile(filename string, backup string) error {
+	// Remove any previous backup
+	if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Link or copy the file to the backup
+	if err := os.Link(filename, backup); err != nil {
+		return utils.CopyFile(filename, backup)
+	}
+	return nil
+}
+
 // Example help text for the format command
 const formatExample = `  mactool format 00:00:5e:00:53:01 --lower --delimiter . --group-size 4
   mactool format First address 0000.5E00.5301, second address 00:00:5e:00:53:01, etc. -u -d - -g 2
   cat macs.txt | mactool format --lower --delimiter :
   ip addr | mactool format
+  mactool format --follow -i /var/log/syslog -u -d :
+  mactool format -H -i router1.cfg -i router2.cfg -l -d :
+  mactool format --in-place --backup-suffix .bak -r -i configs/ -l -d . -g 4
+  mactool format --dry-run -r -i configs/ -l -d . -g 4
+  mactool format --template cisco-upper 00:1a:2b:3c:4d:5e
+  mactool format --template "0x{hex}" 00:1a:2b:3c:4d:5e
+  mactool format --template "{{{octets:,}}}" 00:1a:2b:3c:4d:5e
+  mactool format --profile cisco -i switch.cfg
 
 Interactive mode:
   mactool format
@@ -134,7 +453,9 @@ performs reformatting according to the provided argument flags,
 and prints the result to the terminal.
 
 The command takes input in the form of command line arguments,
-standard input (piped data) or interactive input.`
+standard input (piped data), one or more files or interactive input.
+Compressed files (gzip, bzip2, zstd) and archives (zip, tar) are
+detected automatically and read without unpacking them first.`
 
 // formatCmd represents the format command
 var formatCmd = &cobra.Command{
@@ -144,46 +465,12 @@ var formatCmd = &cobra.Command{
 	Example:      formatExample,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Input string to hold the processed input
-		var input string
-		var err error
-
-		// Check if data is being piped, read from file or redirected to stdin
-		if viper.GetString("format.input-file") != "" {
-			// Read input from file
-			input, err = cli.ProcessFile(viper.GetString("format.input-file"))
-			if err != nil {
-				return err
-			}
-		} else if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) == 0 {
-			// Process data from pipe or redirection (stdin)
-			input, err = cli.ProcessStdin()
-			if err != nil {
-				return err
-			}
-		} else {
-			if len(args) == 0 {
-				// If there are no command line arguments,
-				// enter interactive mode and read user input
-				input, err = cli.ProcessInteractiveInput()
-				if err != nil {
-					return err
-				}
-			} else {
-				// If there are command line arguments, join them
-				// into a single string and use that as user input
-				input = strings.Join(args, " ")
-			}
+		// Create the formatter from the template or format flags
+		format, err := createFormatterFromFlags("format")
+		if err != nil {
+			return err
 		}
 
-		// Create a MacFormat struct from the flags
-		format := createMacFormatFromFlags(
-			viper.GetBool("format.upper"),
-			viper.GetBool("format.lower"),
-			viper.GetString("format.delimiter"),
-			viper.GetInt("format.group-size"),
-		)
-
 		// Determine the output file using Viper
 		outputFile := viper.GetString("format.output-file")
 		append := viper.GetBool("format.append")
@@ -200,7 +487,37 @@ var formatCmd = &cobra.Command{
 			debug.PrintConfigDebug()
 		}
 
-		// Format the MAC addresses found in the input string
+		// Rewrite the input files, or show the changes as a diff,
+		// if the --in-place or --dry-run flags are set
+		inPlace := viper.GetBool("format.in-place")
+		dryRun := viper.GetBool("format.dry-run")
+		if inPlace || dryRun {
+			// Rewriting requires input files, which are read only once
+			if viper.GetBool("format.follow") {
+				return errInPlaceWithFollow
+			}
+			backupSuffix := viper.GetString("format.backup-suffix")
+			filenames, err := inPlaceFiles(backupSuffix)
+			if err != nil {
+				return err
+			}
+			if len(filenames) == 0 {
+				return errInPlaceRequiresInputFile
+			}
+
+			// Format the MAC addresses found in the files
+			return formatInPlaceAction(outStream, format, filenames, backupSuffix, dryRun)
+		}
+
+		// Open the input from file, pipe, arguments or interactive mode,
+		// or follow the input file if the --follow flag is set
+		input, err := openInput("format", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		// Format the MAC addresses found in the input
 		// using the format specified by the flags
 		return formatAction(outStream, format, input)
 	},
@@ -226,10 +543,58 @@ func init() {
 	formatCmd.Flags().IntP("group-size", "g", 0, "number of characters in each hex group")
 	viper.BindPFlag("format.group-size", formatCmd.Flags().Lookup("group-size"))
 
-	// Add flag for input file path
-	formatCmd.Flags().StringP("input-file", "i", "", "read input from file")
+	// Add the --template flag to the format command
+	formatCmd.Flags().StringP("template", "t", "", "template such as \"{OCTETS:-}\" or preset ("+strings.Join(mac.PresetNames(), ", ")+"), overrides -u, -l, -d and -g")
+	viper.BindPFlag("format.template", formatCmd.Flags().Lookup("template"))
+
+	// Add the --profile flag to the format command
+	formatCmd.Flags().StringP("profile", "p", "", "format profile from the config file, overrides -u, -l, -d, -g and -t")
+	viper.BindPFlag("format.profile", formatCmd.Flags().Lookup("profile"))
+
+	// Add the --reverse flag to the format command
+	formatCmd.Flags().Bool("reverse", false, "with --template, reverse the order of the octets")
+	viper.BindPFlag("format.reverse", formatCmd.Flags().Lookup("reverse"))
+
+	// Add the --bit-reverse flag to the format command
+	formatCmd.Flags().Bool("bit-reverse", false, "with --template, reverse the bits of each octet (canonical and Token Ring form)")
+	viper.BindPFlag("format.bit-reverse", formatCmd.Flags().Lookup("bit-reverse"))
+
+	// Add flag for input file paths, which may be repeated
+	formatCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
 	viper.BindPFlag("format.input-file", formatCmd.Flags().Lookup("input-file"))
 
+	// Set to the value of the --recursive flag if set
+	formatCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("format.recursive", formatCmd.Flags().Lookup("recursive"))
+
+	// Set to the value of the --with-filename flag if set
+	formatCmd.Flags().BoolP("with-filename", "H", false, "prefix each result with the file name and line number")
+	viper.BindPFlag("format.with-filename", formatCmd.Flags().Lookup("with-filename"))
+
+	// Set to the value of the --loose flag if set
+	formatCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("format.loose", formatCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	formatCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("format.accept", formatCmd.Flags().Lookup("accept"))
+
+	// Set to the value of the --in-place flag if set
+	formatCmd.Flags().Bool("in-place", false, "rewrite the input files instead of printing the result")
+	viper.BindPFlag("format.in-place", formatCmd.Flags().Lookup("in-place"))
+
+	// Set to the value of the --backup-suffix flag if set
+	formatCmd.Flags().String("backup-suffix", "", "with --in-place, keep a copy of each changed file with this suffix (e.g. .bak)")
+	viper.BindPFlag("format.backup-suffix", formatCmd.Flags().Lookup("backup-suffix"))
+
+	// Set to the value of the --dry-run flag if set
+	formatCmd.Flags().Bool("dry-run", false, "print a unified diff of the changes instead of rewriting the input files")
+	viper.BindPFlag("format.dry-run", formatCmd.Flags().Lookup("dry-run"))
+
+	// Set to the value of the --follow flag if set
+	formatCmd.Flags().BoolP("follow", "f", false, "keep reading lines appended to the input file, like tail -F")
+	viper.BindPFlag("format.follow", formatCmd.Flags().Lookup("follow"))
+
 	// Add flag for output file path
 	formatCmd.Flags().StringP("output-file", "o", "", "write output to file")
 	viper.BindPFlag("format.output-file", formatCmd.Flags().Lookup("output-file"))
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/bitcanon/mactool/cli"
 	"github.com/bitcanon/mactool/mac"
 	"github.com/bitcanon/mactool/utils"
 	"github.com/spf13/viper"
@@ -173,6 +177,16 @@ func TestFormatAction(t *testing.T) {
 				GroupSize: mac.GroupSizeFour,
 			},
 		},
+		{
+			name:     "FormatOnlyTheMatchedText",
+			input:    "EUI-48 02:00:5e:10:00:00 and EUI-64 02:00:5e:10:00:00:00:01",
+			expected: "EUI-48 0200.5e10.0000 and EUI-64 0200.5e10.0000.0001\n",
+			format: mac.MacFormat{
+				Case:      mac.Lower,
+				Delimiter: mac.Dot,
+				GroupSize: mac.GroupSizeFour,
+			},
+		},
 	}
 
 	// Loop through the test cases and run each test
@@ -182,7 +196,7 @@ func TestFormatAction(t *testing.T) {
 			var output strings.Builder
 
 			// Call the formatAction function
-			err := formatAction(&output, test.format, test.input)
+			err := formatAction(&output, test.format, newStringInput(test.input))
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -260,7 +274,7 @@ Second line of input with one MAC address 00:00:5e:00:53:02 in it.` + "\n",
 		defer outStream.Close()
 
 		// Call the function to test
-		err = formatAction(outStream, test.format, test.input)
+		err = formatAction(outStream, test.format, newStringInput(test.input))
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 			return
@@ -279,3 +293,256 @@ Second line of input with one MAC address 00:00:5e:00:53:02 in it.` + "\n",
 		}
 	}
 }
+
+// TestFormatActionTemplate tests the formatAction function
+// with templates and presets created from the flags
+func TestFormatActionTemplate(t *testing.T) {
+	input := "interface Gi0/1 mac 00:1a:2b:3c:4d:5e vlan 10"
+
+	// Setup test cases
+	testCases := []struct {
+		name       string
+		template   string
+		reverse    bool
+		bitReverse bool
+		expected   string
+	}{
+		{"Preset", "cisco-upper", false, false, "interface Gi0/1 mac 001A.2B3C.4D5E vlan 10\n"},
+		{"Pattern", "{{{octets:,}}}", false, false, "interface Gi0/1 mac {00,1a,2b,3c,4d,5e} vlan 10\n"},
+		{"PresetReversed", "windows", true, false, "interface Gi0/1 mac 5E-4D-3C-2B-1A-00 vlan 10\n"},
+		{"PatternBitReversed", "{octets::}", false, true, "interface Gi0/1 mac 00:58:d4:3c:b2:7a vlan 10\n"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("format.template", test.template)
+			viper.Set("format.reverse", test.reverse)
+			viper.Set("format.bit-reverse", test.bitReverse)
+			defer viper.Set("format.template", "")
+			defer viper.Set("format.reverse", false)
+			defer viper.Set("format.bit-reverse", false)
+
+			// Create the formatter from the flags
+			format, err := createFormatterFromFlags("format")
+			if err != nil {
+				t.Fatalf("error returned from createFormatterFromFlags(): %v", err)
+			}
+
+			// Call the function to test
+			var output bytes.Buffer
+			if err := formatAction(&output, format, newStringInput(input)); err != nil {
+				t.Fatalf("error returned from formatAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
+
+// TestFormatActionDecimal tests the formatAction function with MAC
+// addresses in the dotted decimal index of SNMP OIDs
+func TestFormatActionDecimal(t *testing.T) {
+	viper.Set("format.accept", []string{"decimal"})
+	defer viper.Set("format.accept", nil)
+
+	// Format the MAC addresses as upper case with hyphens
+	format := mac.MacFormat{Case: mac.Upper, Delimiter: mac.Hyphen, GroupSize: mac.GroupSizeTwo}
+
+	// Call the function to test
+	var output bytes.Buffer
+	input := "SNMPv2-SMI::mib-2.17.4.3.1.2.0.26.43.60.77.94 = INTEGER: 12"
+	if err := formatAction(&output, format, newStringInput(input)); err != nil {
+		t.Fatalf("error returned from formatAction(): %v", err)
+	}
+
+	// Compare the results to the expected values
+	expected := "SNMPv2-SMI::mib-2.17.4.3.1.2.00-1A-2B-3C-4D-5E = INTEGER: 12\n"
+	if output.String() != expected {
+		t.Errorf("expected %q, but got %q", expected, output.String())
+	}
+}
+
+// TestFormatInPlaceAction tests the formatInPlaceAction function
+// by rewriting files, with and without backups, and by dry runs
+func TestFormatInPlaceAction(t *testing.T) {
+	format := mac.MacFormat{Case: mac.Lower, Delimiter: mac.Dot, GroupSize: mac.GroupSizeFour}
+	original := "interface eth0\r\n mac 00:00:5E:00:53:01\r\n!\r\nno mac here\nlast 00-00-5e-00-53-02"
+	formatted := "interface eth0\r\n mac 0000.5e00.5301\r\n!\r\nno mac here\nlast 0000.5e00.5302"
+
+	testCases := []struct {
+		name         string
+		content      string
+		backupSuffix string
+		dryRun       bool
+		expected     string
+		expectedOut  string
+	}{
+		{
+			name:     "Rewrite keeping line endings",
+			content:  original,
+			expected: formatted,
+		},
+		{
+			name:         "Rewrite with backup",
+			content:      original,
+			backupSuffix: ".bak",
+			expected:     formatted,
+		},
+		{
+			name:     "Dry run",
+			content:  original,
+			dryRun:   true,
+			expected: original,
+			expectedOut: `--- {file}
++++ {file}
+@@ -1,5 +1,5 @@
+ interface eth0
+- mac 00:00:5E:00:53:01
++ mac 0000.5e00.5301
+ !
+ no mac here
+-last 00-00-5e-00-53-02
+\ No newline at end of file
++last 0000.5e00.5302
+\ No newline at end of file
+`,
+		},
+		{
+			name:         "Unchanged file",
+			content:      "no mac here\n",
+			backupSuffix: ".bak",
+			expected:     "no mac here\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Create the file to rewrite
+			dir := t.TempDir()
+			filename := filepath.Join(dir, "config")
+			if err := os.WriteFile(filename, []byte(test.content), 0640); err != nil {
+				t.Fatalf("failed to create file: %v", err)
+			}
+
+			// Call the function to test
+			var out bytes.Buffer
+			err := formatInPlaceAction(&out, format, []string{filename}, test.backupSuffix, test.dryRun)
+			if err != nil {
+				t.Fatalf("error returned from formatInPlaceAction(): %v", err)
+			}
+
+			// Compare the file content and the output
+			actual, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+			if string(actual) != test.expected {
+				t.Errorf("expected file content %q, but got %q", test.expected, string(actual))
+			}
+			expectedOut := strings.ReplaceAll(test.expectedOut, "{file}", filename)
+			if out.String() != expectedOut {
+				t.Errorf("expected output %q, but got %q", expectedOut, out.String())
+			}
+
+			// The permissions of the file are kept
+			info, err := os.Stat(filename)
+			if err != nil {
+				t.Fatalf("failed to stat file: %v", err)
+			}
+			if info.Mode().Perm() != 0640 {
+				t.Errorf("expected permissions 0640, but got %o", info.Mode().Perm())
+			}
+
+			// A backup is only kept of changed files
+			backup, err := os.ReadFile(filename + test.backupSuffix)
+			changed := test.content != test.expected
+			if test.backupSuffix != "" && changed {
+				if err != nil || string(backup) != test.content {
+					t.Errorf("expected backup with original content, but got %q (%v)", string(backup), err)
+				}
+			} else if test.backupSuffix != "" {
+				if !os.IsNotExist(err) {
+					t.Errorf("expected no backup of unchanged file, but got %v", err)
+				}
+			}
+
+			// No temporary files are left behind
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("failed to read directory: %v", err)
+			}
+			for _, entry := range entries {
+				if strings.HasSuffix(entry.Name(), ".tmp") {
+					t.Errorf("temporary file %s left behind", entry.Name())
+				}
+			}
+		})
+	}
+}
+
+// TestFormatInPlaceTwice tests that rewriting the files of a directory
+// twice with backups does not format and back up the backups
+func TestFormatInPlaceTwice(t *testing.T) {
+	format := mac.MacFormat{Case: mac.Lower, Delimiter: mac.Dot, GroupSize: mac.GroupSizeFour}
+	original := "mac 00:00:5E:00:53:01\n"
+
+	// Create the directory of files to rewrite
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config")
+	if err := os.WriteFile(filename, []byte(original), 0640); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	// Set the flags
+	viper.Set("format.input-file", []string{dir})
+	viper.Set("format.recursive", true)
+	defer viper.Set("format.input-file", nil)
+	defer viper.Set("format.recursive", false)
+
+	// Run the command twice
+	for run := 1; run <= 2; run++ {
+		filenames, err := inPlaceFiles(".bak")
+		if err != nil {
+			t.Fatalf("error returned from inPlaceFiles(): %v", err)
+		}
+		if err := formatInPlaceAction(io.Discard, format, filenames, ".bak", false); err != nil {
+			t.Fatalf("error returned from formatInPlaceAction(): %v", err)
+		}
+	}
+
+	// Only the file and its backup of the original content are kept
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, " ") != "config config.bak" {
+		t.Errorf("expected config config.bak, but got %v", names)
+	}
+	backup, err := os.ReadFile(filename + ".bak")
+	if err != nil || string(backup) != original {
+		t.Errorf("expected backup with original content, but got %q (%v)", string(backup), err)
+	}
+}
+
+// BenchmarkFormatAction benchmarks the formatAction function
+// on a generated input of benchmarkInputSize bytes
+func BenchmarkFormatAction(b *testing.B) {
+	format := mac.MacFormat{Case: mac.Upper, Delimiter: mac.Hyphen, GroupSize: mac.GroupSizeTwo}
+
+	b.ReportAllocs()
+	b.SetBytes(benchmarkInputSize)
+	for i := 0; i < b.N; i++ {
+		err := formatAction(io.Discard, format, cli.NewReaderInput("generated", io.NopCloser(newGeneratedInput(benchmarkInputSize))))
+		if err != nil {
+			b.Fatalf("error returned from formatAction(): %v", err)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/spf13/viper"
+)
+
+// Errors returned when the follow flags are used incorrectly
+var errFollowRequiresInputFile = errors.New("--follow requires exactly one input file (--input-file)")
+var errFollowWithSort = errors.New("--follow cannot be used with --sort-asc, --sort-desc or --sort-by")
+var errNewOnlyRequiresFollow = errors.New("--new-only can only be used with --follow")
+var errFollowWithUnique = errors.New("--follow cannot be used with --unique or --count, use --new-only instead")
+
+// followInput is the input of a command when following a file.
+// Closing it stops listening for interrupt signals.
+type followInput struct {
+	io.ReadCloser
+	stop context.CancelFunc
+}
+
+// Close closes the followed file and stops listening for signals
+func (f *followInput) Close() error {
+	f.stop()
+	return f.ReadCloser.Close()
+}
+
+// openInput opens the input of the specified command from files, pipe,
+// arguments or interactive mode. If the --follow flag of the command is set,
+// the input file is followed for new lines until the user interrupts it.
+func openInput(command string, args []string) (cli.Input, error) {
+	// Expand glob patterns and directories into the list of input files
+	filenames, err := cli.ExpandFiles(
+		viper.GetStringSlice(command+".input-file"),
+		viper.GetBool(command+".recursive"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the input once if the input file is not followed
+	if !viper.GetBool(command + ".follow") {
+		if viper.GetBool(command + ".new-only") {
+			return nil, errNewOnlyRequiresFollow
+		}
+		return cli.OpenInput(filenames, args)
+	}
+
+	// Following requires a single file, and sorting would never finish
+	if len(filenames) != 1 {
+		return nil, errFollowRequiresInputFile
+	}
+	if viper.GetBool(command+".sort-asc") || viper.GetBool(command+".sort-desc") || viper.GetString(command+".sort-by") != "" {
+		return nil, errFollowWithSort
+	}
+	if viper.GetBool(command+".unique") || viper.GetBool(command+".count") {
+		return nil, errFollowWithUnique
+	}
+
+	// Stop following the file when the user presses CTRL+C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	// Follow the input file for new lines
+	file, err := cli.FollowFile(ctx, filenames[0])
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	return cli.NewReaderInput(filenames[0], &followInput{ReadCloser: file, stop: stop}), nil
+}
+
+// Usage text of the --loose and --accept flags, shared by
+// the commands that find MAC addresses in the input
+const looseUsage = "also find MAC-like text inside longer tokens, such as IPv6 addresses and UUIDs"
+const acceptUsage = "also find MAC addresses in these notations: bare (00005E005301), space (00 00 5e 00 53 01), hex (0x00005e005301), decimal (0.0.94.0.83.1)"
+
+// newScanner returns the scanner used to find MAC addresses
+// in the input, configured by the flags of the specified command
+func newScanner(command string) (mac.Scanner, error) {
+	scanner := mac.Scanner{Loose: viper.GetBool(command + ".loose")}
+
+	// Add the notations listed by the --accept flag
+	for _, name := range viper.GetStringSlice(command + ".accept") {
+		notation, err := mac.ParseNotation(name)
+		if err != nil {
+			return mac.Scanner{}, fmt.Errorf("--accept %s: %w", name, err)
+		}
+		scanner.Accept = append(scanner.Accept, notation)
+	}
+	return scanner, nil
+}
+
+// result is a MAC address found in the input, along with the name
+// of the input and the line and column it was found on. Results merged
+// by uniqueResults also have the number of times the MAC address was
+// found and the name of the input and the line it was last found on.
+type result struct {
+	source     string
+	number     int
+	column     int
+	address    string
+	count      int
+	lastSource string
+	lastNumber int
+}
+
+// newResults returns the MAC addresses found on the line as results
+func newResults(line cli.Line, matches []mac.Match) []result {
+	results := make([]result, len(matches))
+	for i, m := range matches {
+		results[i] = result{source: line.Source, number: line.Number, column: m.Column, address: m.Address()}
+	}
+	return results
+}
+
+// prefix returns the prefix printed before the result. The file name and
+// line number are included if withFilename is set (file:line:), and the line
+// number and column are included if withLineNumbers is set (line:column:).
+func (r result) prefix(withFilename bool, withLineNumbers bool) string {
+	switch {
+	case withFilename && withLineNumbers:
+		return fmt.Sprintf("%s:%d:%d:", r.source, r.number, r.column)
+	case withFilename:
+		return fmt.Sprintf("%s:%d:", r.source, r.number)
+	case withLineNumbers:
+		return fmt.Sprintf("%d:%d:", r.number, r.column)
+	default:
+		return ""
+	}
+}
+
+// seenOn returns the first and last lines the MAC address was found on,
+// as in "first 4 last 1020". The lines are prefixed with the file name
+// if withFilename is set, as in "first a.log:4 last b.log:1020".
+func (r result) seenOn(withFilename bool) string {
+	first, last := strconv.Itoa(r.number), strconv.Itoa(r.lastNumber)
+	if withFilename {
+		first, last = r.source+":"+first, r.lastSource+":"+last
+	}
+	return fmt.Sprintf("first %s last %s", first, last)
+}
+
+// uniqueResults returns the results with each MAC address once, at the
+// position it was first found, along with the number of times it was found
+// and the position it was last found. MAC addresses are compared by value,
+// so 0011.2233.4455 and 00:11:22:33:44:55 are the same MAC address.
+func uniqueResults(results []result) []result {
+	var unique []result
+	index := map[string]int{}
+	for _, r := range results {
+		key := addressKey(r.address)
+		i, ok := index[key]
+		if !ok {
+			i = len(unique)
+			index[key] = i
+			unique = append(unique, r)
+		}
+		unique[i].count++
+		unique[i].lastSource, unique[i].lastNumber = r.source, r.number
+	}
+	return unique
+}
+
+// sortResults sorts the results by the numeric value of their MAC
+// addresses, in ascending or descending order, regardless of notation
+// and case. Results with equal addresses are kept in the order they
+// were found.
+func sortResults(results []result, descending bool) {
+	sortResultsBy(results, descending, func(a result, b result) int {
+		return compareAddresses(a.address, b.address)
+	})
+}
+
+// sortResultsBy sorts the results in ascending or descending order by the
+// compare function, which returns a negative number if a is before b, zero
+// if they are equal and a positive number if a is after b. Equal results
+// are kept in the order they were found in either order.
+func sortResultsBy(results []result, descending bool, compare func(a result, b result) int) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if descending {
+			return compare(results[i], results[j]) > 0
+		}
+		return compare(results[i], results[j]) < 0
+	})
+}
+
+// compareAddresses compares the MAC addresses by their numeric value,
+// with EUI-48 addresses before EUI-64 addresses
+func compareAddresses(a string, b string) int {
+	if c, err := mac.Compare(a, b); err == nil {
+		return c
+	}
+
+	// Compare addresses of different lengths by their number of hex digits
+	x, y := addressKey(a), addressKey(b)
+	if len(x) != len(y) {
+		return len(x) - len(y)
+	}
+	return strings.Compare(x, y)
+}
+
+// seenAddresses keeps track of the MAC addresses seen so far. Addresses
+// are compared by value, so 0000.5e00.5301 and 00:00:5E:00:53:01 are equal.
+type seenAddresses map[string]bool
+
+// filterNew returns the MAC addresses that have not been seen
+// before and marks all of the MAC addresses as seen.
+func (s seenAddresses) filterNew(matches []mac.Match) []mac.Match {
+	var unseen []mac.Match
+	for _, m := range matches {
+		key := addressKey(m.Address())
+		if !s[key] {
+			s[key] = true
+			unseen = append(unseen, m)
+		}
+	}
+	return unseen
+}
+
+// addressKey returns the MAC address as upper case hex digits only,
+// so that MAC addresses in different notations compare equal
+func addressKey(address string) string {
+	key, err := mac.FormatMacAddress(address, mac.MacFormat{Case: mac.Upper, Delimiter: mac.None, GroupSize: mac.GroupSizeTwo})
+	if err != nil {
+		return address
+	}
+	return key
+}
+
+// Usage text of the --unique and --count flags, shared by
+// the commands that find MAC addresses in the input
+const uniqueUsage = "print each MAC address only once, in any notation, where it was first found"
+const countUsage = "print each MAC address once with the number of times and the first and last lines it was found on"
+
+// Usage text of the --match-prefix flag, shared by the commands
+// that find MAC addresses in the input
+const matchPrefixUsage = "only find MAC addresses in this prefix, such as 00:1a:2b:00:00:00/28 or 00:1a:2b:* (may be repeated)"
+
+// prefixFilter is the list of prefixes the MAC addresses must be in
+type prefixFilter []mac.Prefix
+
+// newPrefixFilter returns the prefixes given with the --match-prefix
+// flag of the specified command, or nil if the flag is not set
+func newPrefixFilter(command string) (prefixFilter, error) {
+	var prefixes prefixFilter
+	for _, s := range viper.GetStringSlice(command + ".match-prefix") {
+		p, err := mac.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("--match-prefix: %w", err)
+		}
+		prefixes = append(prefixes, p)
+	}
+	return prefixes, nil
+}
+
+// filter returns the MAC addresses that are in any of the prefixes
+func (f prefixFilter) filter(matches []mac.Match) []mac.Match {
+	var matching []mac.Match
+	for _, m := range matches {
+		for _, p := range f {
+			if p.Contains(m.Address()) {
+				matching = append(matching, m)
+				break
+			}
+		}
+	}
+	return matching
+}
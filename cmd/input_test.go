@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/mac"
+)
+
+// TestSeenAddressesFilterNew tests the filterNew method of seenAddresses
+func TestSeenAddressesFilterNew(t *testing.T) {
+	seen := seenAddresses{}
+
+	// Setup test cases, run in order against the same set of seen addresses
+	testCases := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{
+			name:     "AllNew",
+			input:    []string{"00:00:5e:00:53:01", "00:00:5e:00:53:02"},
+			expected: []string{"00:00:5e:00:53:01", "00:00:5e:00:53:02"},
+		},
+		{
+			name:     "SameAddressInOtherNotation",
+			input:    []string{"0000.5E00.5301", "00-00-5E-00-53-03"},
+			expected: []string{"00-00-5E-00-53-03"},
+		},
+		{
+			name:     "DuplicatesInSameLine",
+			input:    []string{"00:00:5e:00:53:04", "00:00:5E:00:53:04"},
+			expected: []string{"00:00:5e:00:53:04"},
+		},
+		{
+			name:     "NothingNew",
+			input:    []string{"00:00:5e:00:53:01", "00:00:5e:00:53:04"},
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Compare the text of the MAC addresses that were kept
+			var input []mac.Match
+			for _, m := range test.input {
+				input = append(input, mac.Match{Text: m})
+			}
+			var actual []string
+			for _, m := range seen.filterNew(input) {
+				actual = append(actual, m.Text)
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %q, but got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+// TestUniqueResults tests that uniqueResults merges the results of
+// MAC addresses in any notation, keeping the first and last positions
+func TestUniqueResults(t *testing.T) {
+	results := []result{
+		{source: "a.log", number: 1, column: 5, address: "0011.2233.4455"},
+		{source: "a.log", number: 2, column: 1, address: "00:11:22:33:44:66"},
+		{source: "b.log", number: 7, column: 3, address: "00-11-22-33-44-55"},
+		{source: "b.log", number: 9, column: 1, address: "00:11:22:33:44:55"},
+	}
+	expected := []result{
+		{source: "a.log", number: 1, column: 5, address: "0011.2233.4455", count: 3, lastSource: "b.log", lastNumber: 9},
+		{source: "a.log", number: 2, column: 1, address: "00:11:22:33:44:66", count: 1, lastSource: "a.log", lastNumber: 2},
+	}
+
+	actual := uniqueResults(results)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, but got %+v", expected, actual)
+	}
+
+	// Check the lines the first MAC address was found on
+	if seen := actual[0].seenOn(true); seen != "first a.log:1 last b.log:9" {
+		t.Errorf("expected %q, but got %q", "first a.log:1 last b.log:9", seen)
+	}
+}
+
+// newStringInput returns an input reading lines from the string s
+func newStringInput(s string) cli.Input {
+	return cli.NewReaderInput(cli.ArgumentsName, io.NopCloser(strings.NewReader(s)))
+}
@@ -0,0 +1,244 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/debug"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/bitcanon/mactool/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// inspectRecord is what is known about a MAC address without the OUI
+// database, as printed by the inspect command
+type inspectRecord struct {
+	MacAddress    string             `json:"mac"`
+	Notation      string             `json:"notation"`
+	Bits          int                `json:"bits"`
+	Canonical     string             `json:"canonical"`
+	Assignment    string             `json:"assignment"`
+	Multicast     bool               `json:"multicast"`
+	Local         bool               `json:"local"`
+	Special       string             `json:"special,omitempty"`
+	VirtualRouter *mac.VirtualRouter `json:"virtual_router,omitempty"`
+}
+
+// newInspectRecord returns what is known about the MAC address found
+func newInspectRecord(m mac.Match) (inspectRecord, error) {
+	address := m.Address()
+	record := inspectRecord{MacAddress: m.Text, Notation: m.Notation.String()}
+
+	// Get the canonical form and the OUI assignment
+	canonical, err := mac.FormatMacAddress(address, mac.MacFormat{Case: mac.Lower, Delimiter: mac.Colon, GroupSize: mac.GroupSizeTwo})
+	if err != nil {
+		return inspectRecord{}, err
+	}
+	record.Canonical = canonical
+	record.Bits = (len(canonical) + 1) / 3 * 8
+	if record.Assignment, err = mac.ExtractOuiFromMac(address); err != nil {
+		return inspectRecord{}, err
+	}
+
+	// Get the group and administration bits of the first octet
+	if record.Multicast, err = mac.IsMulticast(address); err != nil {
+		return inspectRecord{}, err
+	}
+	if record.Local, err = mac.IsLocallyAdministered(address); err != nil {
+		return inspectRecord{}, err
+	}
+
+	// Describe well-known addresses and virtual router addresses
+	record.Special, _ = mac.LookupSpecial(address)
+	if vr, ok := mac.DecodeVirtualRouter(address); ok {
+		record.VirtualRouter = &vr
+	}
+	return record, nil
+}
+
+// print writes the record to the output writer as an indented block
+func (record inspectRecord) print(out io.Writer) {
+	cast := "unicast"
+	if record.Multicast {
+		cast = "multicast"
+	}
+	administration := "universally administered (UAA)"
+	if record.Local {
+		administration = "locally administered (LAA)"
+	}
+
+	fmt.Fprintln(out, record.MacAddress)
+	fmt.Fprintf(out, "  notation:       %s (EUI-%d)\n", record.Notation, record.Bits)
+	fmt.Fprintf(out, "  canonical:      %s\n", record.Canonical)
+	fmt.Fprintf(out, "  oui:            %s\n", record.Assignment)
+	fmt.Fprintf(out, "  type:           %s\n", cast)
+	fmt.Fprintf(out, "  administration: %s\n", administration)
+	if record.Special != "" {
+		fmt.Fprintf(out, "  special:        %s\n", record.Special)
+	}
+	if record.VirtualRouter != nil {
+		fmt.Fprintf(out, "  virtual router: %s\n", record.VirtualRouter)
+	}
+}
+
+// inspectAction finds the MAC addresses in the input, line by line, and
+// prints what is known about each of them without the OUI database: the
+// notation, the unicast/multicast and UAA/LAA bits, well-known addresses
+// and the virtual router of VRRP, HSRP, GLBP and CARP addresses.
+func inspectAction(out io.Writer, input cli.Input) error {
+	// Buffer the output since it is written one address at a time
+	w := bufio.NewWriter(out)
+
+	// Write in JSON format if the --json flag is set, one object per line
+	asJSON := viper.GetBool("inspect.json")
+
+	// Find the MAC addresses with the scanner configured by the flags
+	scanner, err := newScanner("inspect")
+	if err != nil {
+		return err
+	}
+
+	// Process the input one line at a time
+	first := true
+	err = input.Scan(func(line cli.Line) error {
+		for _, m := range scanner.FindAll(line.Text) {
+			record, err := newInspectRecord(m)
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.Text, err)
+			}
+
+			// Print the record, separated from the previous one by an empty line
+			if asJSON {
+				if err := json.NewEncoder(w).Encode(record); err != nil {
+					return err
+				}
+				continue
+			}
+			if !first {
+				fmt.Fprintln(w)
+			}
+			first = false
+			record.print(w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write any buffered output to the output writer
+	return w.Flush()
+}
+
+// Example help text for the inspect command
+const inspectExample = `  mactool inspect 00:00:5e:00:01:0a 0000.0c07.ac1f
+  show standby brief | mactool inspect
+  mactool inspect --json -i arp-table.txt
+
+Interactive mode:
+  mactool inspect`
+
+// Long help text for the inspect command
+const inspectLong = `Inspect MAC addresses and print what is known about them
+
+For each MAC address found in the input, the notation, canonical
+form, OUI, unicast/multicast bit and universally/locally administered
+bit are printed. Well-known addresses, such as broadcast, LLDP and
+multicast, are described, and the virtual MAC addresses of VRRP,
+HSRP, GLBP and CARP are decoded into protocol, version and group.
+
+Use the lookup command to find the vendors of the MAC addresses.`
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:          "inspect [input]",
+	Short:        "Inspect MAC addresses and print what is known about them",
+	Long:         inspectLong,
+	Example:      inspectExample,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open the input from file, pipe, arguments or interactive mode
+		input, err := openInput("inspect", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		// Determine the output file using Viper
+		outputFile := viper.GetString("inspect.output-file")
+		append := viper.GetBool("inspect.append")
+
+		// Get the output stream
+		outStream, err := utils.GetOutputStream(outputFile, append)
+		if err != nil {
+			return err
+		}
+		defer outStream.Close()
+
+		// Print the configuration debug if the --debug flag is set
+		if viper.GetBool("debug") {
+			debug.PrintConfigDebug()
+		}
+
+		// Inspect the MAC addresses in the input
+		return inspectAction(outStream, input)
+	},
+}
+
+func init() {
+	// Add the inspect command to the root command
+	rootCmd.AddCommand(inspectCmd)
+
+	// Set to the value of the --json flag if set
+	inspectCmd.Flags().BoolP("json", "j", false, "write output in JSON format, one object per line")
+	viper.BindPFlag("inspect.json", inspectCmd.Flags().Lookup("json"))
+
+	// Add flag for input file paths, which may be repeated
+	inspectCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
+	viper.BindPFlag("inspect.input-file", inspectCmd.Flags().Lookup("input-file"))
+
+	// Set to the value of the --recursive flag if set
+	inspectCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("inspect.recursive", inspectCmd.Flags().Lookup("recursive"))
+
+	// Set to the value of the --loose flag if set
+	inspectCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("inspect.loose", inspectCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	inspectCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("inspect.accept", inspectCmd.Flags().Lookup("accept"))
+
+	// Add flag for output file path
+	inspectCmd.Flags().StringP("output-file", "o", "", "write output to file")
+	viper.BindPFlag("inspect.output-file", inspectCmd.Flags().Lookup("output-file"))
+
+	// Set to the value of the --append flag if set
+	inspectCmd.Flags().BoolP("append", "a", false, "append when writing to file with --output-file")
+	viper.BindPFlag("inspect.append", inspectCmd.Flags().Lookup("append"))
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestInspectAction tests the inspectAction function
+func TestInspectAction(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		input    string
+		json     bool
+		expected string
+	}{
+		{
+			name:  "Text",
+			input: "standby 31 virtual 0000.0C07.AC1F, lldp 01:80:c2:00:00:0e",
+			expected: "0000.0C07.AC1F\n" +
+				"  notation:       cisco (EUI-48)\n" +
+				"  canonical:      00:00:0c:07:ac:1f\n" +
+				"  oui:            00000C\n" +
+				"  type:           unicast\n" +
+				"  administration: universally administered (UAA)\n" +
+				"  virtual router: HSRPv1 group 31\n" +
+				"\n" +
+				"01:80:c2:00:00:0e\n" +
+				"  notation:       octet (EUI-48)\n" +
+				"  canonical:      01:80:c2:00:00:0e\n" +
+				"  oui:            0180C2\n" +
+				"  type:           multicast\n" +
+				"  administration: universally administered (UAA)\n" +
+				"  special:        IEEE 802.1 LLDP multicast\n",
+		},
+		{
+			name:  "JSON",
+			input: "00-00-5E-00-01-0A 02:42:ac:11:00:02",
+			json:  true,
+			expected: `{"mac":"00-00-5E-00-01-0A","notation":"octet","bits":48,"canonical":"00:00:5e:00:01:0a","assignment":"00005E","multicast":false,"local":false,"virtual_router":{"protocol":"VRRP","family":"IPv4","group":10}}` + "\n" +
+				`{"mac":"02:42:ac:11:00:02","notation":"octet","bits":48,"canonical":"02:42:ac:11:00:02","assignment":"0242AC","multicast":false,"local":true}` + "\n",
+		},
+		{
+			name:     "NoAddresses",
+			input:    "no MAC addresses here",
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("inspect.json", test.json)
+			defer viper.Set("inspect.json", false)
+
+			// Call the function to test
+			var output bytes.Buffer
+			if err := inspectAction(&output, newStringInput(test.input)); err != nil {
+				t.Fatalf("error returned from inspectAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
@@ -22,11 +22,14 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
-	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -39,39 +42,266 @@ import (
 	"github.com/bitcanon/mactool/utils"
 )
 
-// lookupAction extracts MAC addresses from the input string,
+// lookupAction extracts MAC addresses from the input, line by line,
 // performs vendor lookup, and prints the result to the output writer.
-func lookupAction(out io.Writer, ouiCsvFile io.Reader, s string) error {
-	// Extract MAC addresses from string
-	macs, err := mac.FindAllMacAddresses(s)
+// If the output is to be sorted, all addresses are collected before printing.
+func lookupAction(out io.Writer, ouiCsvFile io.Reader, input cli.Input) error {
+	// Load the OUI database into memory
+	db, err := oui.LoadDatabase(ouiCsvFile)
 	if err != nil {
 		return err
 	}
 
-	// Sort MAC addresses in ascending or descending order
-	if viper.GetBool("lookup.sort-asc") {
-		sort.Strings(macs)
-	} else if viper.GetBool("lookup.sort-desc") {
-		sort.Sort(sort.Reverse(sort.StringSlice(macs)))
+	// Buffer the output since it is written one address at a time
+	w := bufio.NewWriter(out)
+
+	// Sorting and counting require all MAC addresses to be known before printing
+	sortAsc := viper.GetBool("lookup.sort-asc")
+	sortDesc := viper.GetBool("lookup.sort-desc")
+	sortBy := viper.GetString("lookup.sort-by")
+	unique := viper.GetBool("lookup.unique")
+	count := viper.GetBool("lookup.count")
+	collect := sortAsc || sortDesc || sortBy != "" || unique || count
+	if sortBy != "" && !containsString(lookupSortKeys, sortBy) {
+		return fmt.Errorf("--sort-by %s: %w; must be one of %s", sortBy, errInvalidSortKey, strings.Join(lookupSortKeys, ", "))
+	}
+	var results []result
+
+	// When following a file, the output is written as soon as the
+	// addresses are found, optionally only the first time they are seen
+	follow := viper.GetBool("lookup.follow")
+	var seen seenAddresses
+	if viper.GetBool("lookup.new-only") {
+		seen = seenAddresses{}
 	}
 
-	// Load the OUI database into memory
-	db, err := oui.LoadDatabase(ouiCsvFile)
+	// Prefix each result with the file name and line number if requested
+	withFilename := viper.GetBool("lookup.with-filename")
+
+	// Print the MAC addresses in the format of the profile, if set
+	var format mac.Formatter
+	if viper.GetString("lookup.profile") != "" {
+		if format, err = createFormatterFromFlags("lookup"); err != nil {
+			return err
+		}
+	}
+
+	// Load the categories, such as virtual, extended by the config file
+	categories, err := loadCategories()
+	if err != nil {
+		return err
+	}
+
+	// Find the MAC addresses with the scanner configured by the flags
+	scanner, err := newScanner("lookup")
+	if err != nil {
+		return err
+	}
+
+	// Only keep the MAC addresses in the prefixes of the --match-prefix flag
+	prefixes, err := newPrefixFilter("lookup")
+	if err != nil {
+		return err
+	}
+
+	// Process the input one line at a time
+	err = input.Scan(func(line cli.Line) error {
+		// Extract MAC addresses from the line
+		found := scanner.FindAll(line.Text)
+		if prefixes != nil {
+			found = prefixes.filter(found)
+		}
+
+		// Skip the MAC addresses that have already been seen
+		if seen != nil {
+			found = seen.filterNew(found)
+		}
+
+		// Save the MAC addresses for later if the output is sorted or counted
+		if collect {
+			results = append(results, newResults(line, found)...)
+			return nil
+		}
+
+		// Print the vendors of the MAC addresses found in the line
+		if err := printVendors(w, db, categories, newResults(line, found), withFilename, format); err != nil {
+			return err
+		}
+
+		// Write the output right away when following a file
+		if follow {
+			return w.Flush()
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
+	// Keep each MAC address once, counting the times it was found
+	if unique || count {
+		results = uniqueResults(results)
+	}
+
+	// Sort the results by the key of the --sort-by flag, or by MAC
+	// address, in ascending or descending order
+	if sortBy != "" {
+		if err := sortLookupResults(results, sortBy, sortDesc, db, categories); err != nil {
+			return err
+		}
+	} else if sortAsc {
+		sortResults(results, false)
+	} else if sortDesc {
+		sortResults(results, true)
+	}
+
+	// Print the vendors of the sorted MAC addresses
+	if err := printVendors(w, db, categories, results, withFilename, format); err != nil {
+		return err
+	}
+
+	// Write any buffered output to the output writer
+	return w.Flush()
+}
+
+// errInvalidSortKey is returned when the --sort-by flag is not one of lookupSortKeys
+var errInvalidSortKey = errors.New("invalid sort key")
+
+// lookupSortKeys are the keys the results of the lookup command can be sorted by
+var lookupSortKeys = []string{"vendor", "mac", "count", "position"}
+
+// sortLookupResults sorts the results by the key, in ascending or descending
+// order. Results with equal keys are kept in the order they were found.
+//
+//   - vendor sorts by organization name, with unmatched MAC addresses last
+//   - mac sorts by the numeric value of the MAC addresses
+//   - count sorts by the number of times the MAC addresses were found
+//   - position sorts by the order the MAC addresses were found in
+func sortLookupResults(results []result, key string, descending bool, db *oui.OuiDb, categories *oui.Categories) error {
+	switch key {
+	case "vendor":
+		// Look up the vendor of each MAC address once
+		vendors := map[string]string{}
+		for _, r := range results {
+			vendor, _, _, err := findVendor(db, categories, r.address)
+			if err != nil {
+				return err
+			}
+			if vendor != nil {
+				vendors[addressKey(r.address)] = strings.ToLower(vendor.Organization)
+			}
+		}
+
+		sortResultsBy(results, descending, func(a result, b result) int {
+			x, y := vendors[addressKey(a.address)], vendors[addressKey(b.address)]
+
+			// Unmatched MAC addresses are last in either order
+			if (x == "") != (y == "") {
+				if (x == "") != descending {
+					return 1
+				}
+				return -1
+			}
+			return strings.Compare(x, y)
+		})
+	case "mac":
+		sortResults(results, descending)
+	case "count":
+		// Count the MAC addresses, unless merged by uniqueResults
+		counts := map[string]int{}
+		for _, r := range results {
+			if r.count > 0 {
+				counts[addressKey(r.address)] = r.count
+			} else {
+				counts[addressKey(r.address)]++
+			}
+		}
+
+		sortResultsBy(results, descending, func(a result, b result) int {
+			return counts[addressKey(a.address)] - counts[addressKey(b.address)]
+		})
+	case "position":
+		// The results are in the order they were found
+		if descending {
+			for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+				results[i], results[j] = results[j], results[i]
+			}
+		}
+	}
+	return nil
+}
+
+// lookupRecord is a MAC address and its vendor as written in JSON format
+type lookupRecord struct {
+	File          string             `json:"file,omitempty"`
+	Line          int                `json:"line,omitempty"`
+	Count         int                `json:"count,omitempty"`
+	LastFile      string             `json:"last_file,omitempty"`
+	LastLine      int                `json:"last_line,omitempty"`
+	MacAddress    string             `json:"mac"`
+	Assignment    string             `json:"assignment"`
+	Organization  string             `json:"organization,omitempty"`
+	Address       string             `json:"address,omitempty"`
+	Category      string             `json:"category,omitempty"`
+	Special       bool               `json:"special,omitempty"`
+	BitReversed   bool               `json:"bit_reversed,omitempty"`
+	VirtualRouter *mac.VirtualRouter `json:"virtual_router,omitempty"`
+}
+
+// printVendors performs vendor lookup on each of the MAC addresses and
+// prints the result to the output writer. If withFilename is set, each
+// result is prefixed with the file name and line number it was found on.
+// If format is not nil, the MAC addresses are printed in that format.
+// The MAC addresses are tagged with their category, such as virtual.
+// If the --count flag is set, the results merged by uniqueResults are
+// printed with the number of times and the lines they were found on.
+func printVendors(out io.Writer, db *oui.OuiDb, categories *oui.Categories, results []result, withFilename bool, format mac.Formatter) error {
+	// Only print the MAC addresses in the categories of the --category flag
+	var onlyCategories []string
+	for _, category := range viper.GetStringSlice("lookup.category") {
+		onlyCategories = append(onlyCategories, strings.ToLower(category))
+	}
+	withCount := viper.GetBool("lookup.count")
+
+	// Only add the category column to CSV output if categories are requested,
+	// so that the columns are unchanged for scripts that do not use them
+	withCategory := len(onlyCategories) > 0 || viper.GetString("category-rules") != "" ||
+		len(viper.GetStringMap("categories")) > 0
+
 	// Print MAC addresses found in the input string
 	// to the output writer
-	for _, macAddress := range macs {
+	for _, r := range results {
+		macAddress := r.address
+
 		// Lookup the vendor of the MAC address
-		assignment, err := mac.ExtractOuiFromMac(macAddress)
+		vendor, assignment, special, err := findVendor(db, categories, macAddress)
 		if err != nil {
 			return err
 		}
 
-		// Lookup the vendor in the OUI database
-		vendor := db.FindOuiByAssignment(assignment)
+		// Decode the virtual MAC addresses of VRRP, HSRP, GLBP and CARP
+		vr, virtual := mac.DecodeVirtualRouter(macAddress)
+
+		// Try the bit-reversed form of the MAC address if the vendor
+		// was not found and the --try-bit-reversed flag is set
+		bitReversed := false
+		if vendor == nil && viper.GetBool("lookup.try-bit-reversed") {
+			reversedVendor, reversedAssignment, err := lookupBitReversed(db, macAddress)
+			if err != nil {
+				return err
+			}
+			if reversedVendor != nil {
+				vendor, assignment, bitReversed = reversedVendor, reversedAssignment, true
+			}
+		}
+
+		// Find the category of the MAC address by its prefix, or by the
+		// organization, and skip it if it is not in the categories of
+		// the --category flag
+		category, categorized := categories.Classify(macAddress, vendor)
+		if len(onlyCategories) > 0 && !(categorized && containsString(onlyCategories, category)) {
+			continue
+		}
 
 		// Check if the --include flag is set
 		include := viper.GetString("lookup.include")
@@ -97,24 +327,111 @@ func lookupAction(out io.Writer, ouiCsvFile io.Reader, s string) error {
 			}
 		}
 
-		if vendor != nil {
-			// Write in CSV format if the --csv flag is set
-			if viper.GetBool("lookup.csv") {
-				csvRow, err := utils.ConvertStringSliceToCSV([]string{macAddress, vendor.Organization, vendor.Address})
-				if err != nil {
-					return err
+		// Skip the MAC address if the vendor was not found
+		// and the --suppress-unmatched flag is set
+		if vendor == nil && viper.GetBool("lookup.suppress-unmatched") {
+			continue
+		}
+
+		// Print the MAC address in the format of the profile
+		if format != nil {
+			if macAddress, err = format.Format(macAddress); err != nil {
+				return err
+			}
+		}
+
+		// Write in JSON format if the --json flag is set, one object per line
+		if viper.GetBool("lookup.json") {
+			record := lookupRecord{MacAddress: macAddress, Assignment: assignment, Special: special, BitReversed: bitReversed}
+			if withFilename {
+				record.File, record.Line = r.source, r.number
+			}
+			if withCount {
+				record.Line, record.Count, record.LastLine = r.number, r.count, r.lastNumber
+				if withFilename {
+					record.LastFile = r.lastSource
 				}
-				fmt.Fprint(out, csvRow)
-			} else {
-				// If the vendor was found, print the vendor name
-				fmt.Fprintf(out, "%s (%s)\n", macAddress, vendor.Organization)
 			}
+			if vendor != nil {
+				record.Organization, record.Address = vendor.Organization, vendor.Address
+			}
+			if categorized {
+				record.Category = category
+			}
+			if virtual {
+				record.VirtualRouter = &vr
+			}
+			if err := json.NewEncoder(out).Encode(record); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Write in CSV format if the --csv flag is set, with the organization,
+		// address and category left empty if they are not known
+		if viper.GetBool("lookup.csv") {
+			row := []string{macAddress, "", ""}
+			if vendor != nil {
+				row[1], row[2] = vendor.Organization, vendor.Address
+			}
+			if withCategory {
+				row = append(row, category)
+			}
+
+			// Add the file name and line number as the first columns
+			if withFilename {
+				row = append([]string{r.source, strconv.Itoa(r.number)}, row...)
+			}
+
+			// Add the count and the first and last lines as the last columns
+			if withCount {
+				first, last := strconv.Itoa(r.number), strconv.Itoa(r.lastNumber)
+				if withFilename {
+					first, last = r.source+":"+first, r.lastSource+":"+last
+				}
+				row = append(row, strconv.Itoa(r.count), first, last)
+			}
+			csvRow, err := utils.ConvertStringSliceToCSV(row)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(out, csvRow)
+			continue
+		}
+
+		// Tag the MAC address with its category in text output
+		tag := ""
+		if categorized {
+			tag = " [" + category + "]"
+		}
+
+		// Prefix the MAC address with the number of times it was found
+		// instead of its position, followed by the lines it was found on
+		position := r.prefix(withFilename, false)
+		if withCount {
+			position = fmt.Sprintf("%7d ", r.count)
+			tag += " " + r.seenOn(withFilename)
+		}
+
+		if vendor != nil {
+			// If the vendor was found, print the vendor name
+			description := vendor.Organization
+			if bitReversed {
+				description += ", bit-reversed"
+			}
+			if virtual {
+				description += "; " + vr.String()
+			}
+			fmt.Fprint(out, position)
+			fmt.Fprintf(out, "%s (%s)%s\n", macAddress, description, tag)
+		} else if virtual {
+			// If the vendor was not found, print the virtual router
+			fmt.Fprint(out, position)
+			fmt.Fprintf(out, "%s (%s)%s\n", macAddress, vr, tag)
 		} else {
 			// If the vendor was not found, print the MAC address
-			// if the --suppress-unmatched flag is not set
-			if !viper.GetBool("lookup.suppress-unmatched") {
-				fmt.Fprintln(out, macAddress)
-			}
+			fmt.Fprint(out, position)
+			fmt.Fprintf(out, "%s%s\n", macAddress, tag)
 		}
 	}
 
@@ -122,12 +439,61 @@ func lookupAction(out io.Writer, ouiCsvFile io.Reader, s string) error {
 	return nil
 }
 
+// findVendor returns the vendor of the MAC address, or nil if it was not
+// found, the OUI assignment that was looked up, and whether the MAC address
+// is a well-known address. Well-known addresses, such as broadcast, LLDP
+// and multicast, are described by the special address registry, and the
+// MAC addresses in the prefixes of categories by the category rules.
+func findVendor(db *oui.OuiDb, categories *oui.Categories, macAddress string) (*oui.Oui, string, bool, error) {
+	assignment, err := mac.ExtractOuiFromMac(macAddress)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	// Look up well-known addresses before the vendor in the OUI database
+	if description, ok := mac.LookupSpecial(macAddress); ok {
+		return &oui.Oui{Assignment: assignment, Organization: description}, assignment, true, nil
+	}
+	if vendor := db.FindOuiByAssignment(assignment); vendor != nil {
+		return vendor, assignment, false, nil
+	}
+
+	// Platforms using locally administered prefixes, such as Docker and
+	// QEMU/KVM, are not in the OUI database, so the rule describes them
+	if rule, ok := categories.Match(macAddress); ok && rule.Description != "" {
+		return &oui.Oui{Assignment: assignment, Organization: rule.Description}, assignment, false, nil
+	}
+	return nil, assignment, false, nil
+}
+
+// lookupBitReversed looks up the vendor of the bit-reversed form of the MAC
+// address, as written by Token Ring and FDDI tools. It returns the vendor,
+// or nil if it was not found, and the OUI assignment that was looked up.
+func lookupBitReversed(db *oui.OuiDb, macAddress string) (*oui.Oui, string, error) {
+	reversed, err := mac.BitReverse(macAddress)
+	if err != nil {
+		return nil, "", err
+	}
+	assignment, err := mac.ExtractOuiFromMac(reversed)
+	if err != nil {
+		return nil, "", err
+	}
+	return db.FindOuiByAssignment(assignment), assignment, nil
+}
+
 // Example help text for the lookup command
 const lookupExample = `  mactool lookup 00:00:5e:00:53:01
   mactool lookup 0000.5e00.5301 00:00:5e:00:53:01 0000-5e00-5301 00-00-5e-00-53-01
   mactool lookup First address 0000.5E00.5301, second address 00:00:5e:00:53:01, etc.
   cat macs.txt | mactool lookup
   ip addr | mactool lookup
+  mactool lookup --follow --new-only -i /var/log/syslog
+  mactool lookup -H -r -i backups/ --csv
+  mactool lookup --json --profile cisco -i dhcpd.log
+  mactool lookup --try-bit-reversed -i token-ring.log
+  mactool lookup --category virtual -i arp-table.txt
+  mactool lookup --count -H -i "logs/*.log"
+  mactool lookup --count --sort-by count -S -i dhcpd.log
 
 Interactive mode:
   mactool lookup
@@ -139,8 +505,21 @@ process output from a network device containing MAC addresses.`
 const lookupLong = `Extract MAC addresses from the input string, perform
 vendor lookup, and display the result on the terminal.
 
+Well-known addresses, such as broadcast, IEEE 802.1 reserved
+addresses (STP, LACP, LLDP), Cisco protocol addresses and IPv4
+and IPv6 multicast, are described before the OUI database is used.
+
+MAC addresses of hypervisors, container runtimes and cloud NICs,
+such as VMware, QEMU/KVM (52:54:00) and Docker (02:42), are tagged
+with the virtual category. Other MAC addresses are put into device
+categories (networking, iot, voip, printer, mobile, industrial) by
+the organization of their OUI. More categories, prefixes and rules
+can be added in the config file, and --category prints only those.
+
 The command takes input in the form of command line arguments,
-standard input (piped data) or interactive input.`
+standard input (piped data), one or more files or interactive input.
+Compressed files (gzip, bzip2, zstd) and archives (zip, tar) are
+detected automatically and read without unpacking them first.`
 
 // lookupCmd represents the lookup command
 var lookupCmd = &cobra.Command{
@@ -150,38 +529,6 @@ var lookupCmd = &cobra.Command{
 	Example:      lookupExample,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Input string to hold the processed input
-		var input string
-		var err error
-
-		// Check if data is being piped, read from file or redirected to stdin
-		if viper.GetString("lookup.input-file") != "" {
-			// Read input from file
-			input, err = cli.ProcessFile(viper.GetString("lookup.input-file"))
-			if err != nil {
-				return err
-			}
-		} else if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) == 0 {
-			// Process data from pipe or redirection (stdin)
-			input, err = cli.ProcessStdin()
-			if err != nil {
-				return err
-			}
-		} else {
-			if len(args) == 0 {
-				// If there are no command line arguments,
-				// enter interactive mode and read user input
-				input, err = cli.ProcessInteractiveInput()
-				if err != nil {
-					return err
-				}
-			} else {
-				// If there are command line arguments, join them
-				// into a single string and use that as user input
-				input = strings.Join(args, " ")
-			}
-		}
-
 		// Get the OUI database file
 		csv := viper.GetString("lookup.oui-file")
 
@@ -195,6 +542,16 @@ var lookupCmd = &cobra.Command{
 		}
 		defer file.Close()
 
+		// Open the input from file, pipe, arguments or interactive mode,
+		// or follow the input file if the --follow flag is set.
+		// This is done after the database check, since it may prompt
+		// the user for input.
+		input, err := openInput("lookup", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
 		// Determine the output file using Viper
 		outputFile := viper.GetString("lookup.output-file")
 		append := viper.GetBool("lookup.append")
@@ -211,7 +568,7 @@ var lookupCmd = &cobra.Command{
 			debug.PrintConfigDebug()
 		}
 
-		// Extract MAC addresses from string and
+		// Extract MAC addresses from the input and
 		// perform vendor lookup on each address
 		return lookupAction(outStream, file, input)
 	},
@@ -261,10 +618,50 @@ func init() {
 	lookupCmd.PersistentFlags().BoolP("sort-desc", "S", false, "sort output in descending order")
 	viper.BindPFlag("lookup.sort-desc", lookupCmd.PersistentFlags().Lookup("sort-desc"))
 
-	// Add flag for --input-file path
-	lookupCmd.Flags().StringP("input-file", "i", "", "read input from file")
+	// Add flag for the key to sort the output by
+	lookupCmd.Flags().String("sort-by", "", "sort output by vendor, mac, count or position, descending with --sort-desc")
+	viper.BindPFlag("lookup.sort-by", lookupCmd.Flags().Lookup("sort-by"))
+
+	// Set to the value of the --unique flag if set
+	lookupCmd.Flags().BoolP("unique", "U", false, uniqueUsage)
+	viper.BindPFlag("lookup.unique", lookupCmd.Flags().Lookup("unique"))
+
+	// Set to the value of the --count flag if set
+	lookupCmd.Flags().BoolP("count", "C", false, countUsage)
+	viper.BindPFlag("lookup.count", lookupCmd.Flags().Lookup("count"))
+
+	// Add flag for input file paths, which may be repeated
+	lookupCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
 	viper.BindPFlag("lookup.input-file", lookupCmd.Flags().Lookup("input-file"))
 
+	// Set to the value of the --recursive flag if set
+	lookupCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("lookup.recursive", lookupCmd.Flags().Lookup("recursive"))
+
+	// Set to the value of the --with-filename flag if set
+	lookupCmd.Flags().BoolP("with-filename", "H", false, "prefix each result with the file name and line number")
+	viper.BindPFlag("lookup.with-filename", lookupCmd.Flags().Lookup("with-filename"))
+
+	// Set to the value of the --loose flag if set
+	lookupCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("lookup.loose", lookupCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	lookupCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("lookup.accept", lookupCmd.Flags().Lookup("accept"))
+
+	// Add flag for the prefixes to match, which may be repeated
+	lookupCmd.Flags().StringSlice("match-prefix", nil, matchPrefixUsage)
+	viper.BindPFlag("lookup.match-prefix", lookupCmd.Flags().Lookup("match-prefix"))
+
+	// Set to the value of the --follow flag if set
+	lookupCmd.Flags().BoolP("follow", "f", false, "keep reading lines appended to the input file, like tail -F")
+	viper.BindPFlag("lookup.follow", lookupCmd.Flags().Lookup("follow"))
+
+	// Set to the value of the --new-only flag if set
+	lookupCmd.Flags().BoolP("new-only", "n", false, "with --follow, print each MAC address only the first time it is seen")
+	viper.BindPFlag("lookup.new-only", lookupCmd.Flags().Lookup("new-only"))
+
 	// Add flag for --output-file path
 	lookupCmd.PersistentFlags().StringP("output-file", "o", "", "write output to file")
 	viper.BindPFlag("lookup.output-file", lookupCmd.PersistentFlags().Lookup("output-file"))
@@ -273,10 +670,26 @@ func init() {
 	lookupCmd.PersistentFlags().BoolP("append", "a", false, "append when writing to file with --output-file")
 	viper.BindPFlag("lookup.append", lookupCmd.PersistentFlags().Lookup("append"))
 
+	// Set to the value of the --try-bit-reversed flag if set
+	lookupCmd.Flags().Bool("try-bit-reversed", false, "look up the bit-reversed MAC address if the vendor is not found")
+	viper.BindPFlag("lookup.try-bit-reversed", lookupCmd.Flags().Lookup("try-bit-reversed"))
+
+	// Set to the value of the --json flag if set
+	lookupCmd.PersistentFlags().BoolP("json", "j", false, "write output in JSON format, one object per line")
+	viper.BindPFlag("lookup.json", lookupCmd.PersistentFlags().Lookup("json"))
+
+	// Add the --profile flag to the lookup command
+	lookupCmd.Flags().StringP("profile", "p", "", "print MAC addresses in the format of a profile from the config file")
+	viper.BindPFlag("lookup.profile", lookupCmd.Flags().Lookup("profile"))
+
 	// Set to the value of the --csv flag if set
 	lookupCmd.PersistentFlags().BoolP("csv", "c", false, "write output in CSV format")
 	viper.BindPFlag("lookup.csv", lookupCmd.PersistentFlags().Lookup("csv"))
 
+	// Add flag for the categories to print, which may be repeated
+	lookupCmd.Flags().StringSlice("category", nil, "output only MAC addresses in this category, such as virtual (may be repeated)")
+	viper.BindPFlag("lookup.category", lookupCmd.Flags().Lookup("category"))
+
 	// Set to the value of the --include flag if set
 	lookupCmd.Flags().StringP("include", "I", "", "output only results that include this string (case insensitive)")
 	viper.BindPFlag("lookup.include", lookupCmd.Flags().Lookup("include"))
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/bitcanon/mactool/cli"
 	"github.com/bitcanon/mactool/utils"
 	"github.com/spf13/viper"
 )
@@ -148,7 +150,7 @@ MA-L,123ABC,Swede Instruments,Storgatan 1 Stockholm SE 12345`
 			var output strings.Builder
 
 			// Call the function to test
-			err := lookupAction(&output, reader, test.input)
+			err := lookupAction(&output, reader, newStringInput(test.input))
 			if err != nil {
 				t.Errorf("error returned from lookupAction(): %v", err)
 				return
@@ -245,7 +247,7 @@ MA-L,123ABC,Swede Instruments,Storgatan 1 Stockholm SE 12345`
 			defer outStream.Close()
 
 			// Call the function to test
-			err = lookupAction(outStream, reader, test.input)
+			err = lookupAction(outStream, reader, newStringInput(test.input))
 			if err != nil {
 				t.Errorf("error returned from lookupAction(): %v", err)
 				return
@@ -265,3 +267,425 @@ MA-L,123ABC,Swede Instruments,Storgatan 1 Stockholm SE 12345`
 		})
 	}
 }
+
+// TestLookupActionBitReversed tests the lookupAction function with the
+// vendor looked up from the bit-reversed MAC address if not found
+func TestLookupActionBitReversed(t *testing.T) {
+	// Create a test CSV database, in memory
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00005E,"Banana, Inc.",1 Infinite Loop Cupocoffee CA US 12345`
+
+	// The first address is bit-reversed, the second is not found either way
+	input := "00:00:7a:00:ca:80 12:34:56:00:53:01"
+
+	// Set up test cases
+	testCases := []struct {
+		name     string
+		try      bool
+		expected string
+	}{
+		{"Disabled", false, "00:00:7a:00:ca:80\n12:34:56:00:53:01\n"},
+		{"Enabled", true, "00:00:7a:00:ca:80 (Banana, Inc., bit-reversed)\n12:34:56:00:53:01\n"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("lookup.try-bit-reversed", test.try)
+			viper.Set("lookup.suppress-unmatched", false)
+			viper.Set("lookup.sort-asc", false)
+			viper.Set("lookup.sort-desc", false)
+			defer viper.Set("lookup.try-bit-reversed", false)
+
+			// Call the function to test
+			var output strings.Builder
+			if err := lookupAction(&output, strings.NewReader(csvData), newStringInput(input)); err != nil {
+				t.Fatalf("error returned from lookupAction(): %v", err)
+			}
+
+			// Check the output
+			if output.String() != test.expected {
+				t.Errorf("lookupAction() output = %q, want %q", output.String(), test.expected)
+			}
+		})
+	}
+}
+
+// TestLookupActionSpecial tests the lookupAction function with well-known
+// addresses, which are described before the OUI database is used
+func TestLookupActionSpecial(t *testing.T) {
+	// Create a test CSV database, in memory, with the OUI of the IPv4 multicast block
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,01005E,"Banana, Inc.",1 Infinite Loop Cupocoffee CA US 12345`
+
+	input := "01:80:c2:00:00:0e ff-ff-ff-ff-ff-ff 01:00:5e:7f:ff:fa 01:00:5e:80:00:01"
+	expected := "01:80:c2:00:00:0e (IEEE 802.1 LLDP multicast)\n" +
+		"ff-ff-ff-ff-ff-ff (Broadcast)\n" +
+		"01:00:5e:7f:ff:fa (IPv4 multicast SSDP (239.255.255.250))\n" +
+		"01:00:5e:80:00:01 (Banana, Inc.)\n"
+
+	viper.Set("lookup.suppress-unmatched", false)
+	viper.Set("lookup.sort-asc", false)
+	viper.Set("lookup.sort-desc", false)
+
+	// Call the function to test
+	var output strings.Builder
+	if err := lookupAction(&output, strings.NewReader(csvData), newStringInput(input)); err != nil {
+		t.Fatalf("error returned from lookupAction(): %v", err)
+	}
+
+	// Check the output
+	if output.String() != expected {
+		t.Errorf("lookupAction() output = %q, want %q", output.String(), expected)
+	}
+}
+
+// TestLookupActionVirtualRouter tests the lookupAction function with
+// the virtual MAC addresses of first-hop redundancy protocols
+func TestLookupActionVirtualRouter(t *testing.T) {
+	// Create a test CSV database, in memory, without the OUI of HSRP
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00005E,"ICANN, IANA Department",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094`
+
+	input := "00:00:5e:00:01:0a 0000.0c07.ac1f 0000.0c9f.f12c"
+	expected := "00:00:5e:00:01:0a (ICANN, IANA Department; VRRP/CARP IPv4 VRID 10)\n" +
+		"0000.0c07.ac1f (HSRPv1 group 31)\n" +
+		"0000.0c9f.f12c (HSRPv2 group 300)\n"
+
+	viper.Set("lookup.suppress-unmatched", false)
+	viper.Set("lookup.sort-asc", false)
+	viper.Set("lookup.sort-desc", false)
+
+	// Call the function to test
+	var output strings.Builder
+	if err := lookupAction(&output, strings.NewReader(csvData), newStringInput(input)); err != nil {
+		t.Fatalf("error returned from lookupAction(): %v", err)
+	}
+
+	// Check the output
+	if output.String() != expected {
+		t.Errorf("lookupAction() output = %q, want %q", output.String(), expected)
+	}
+}
+
+// TestLookupActionCategory tests the lookupAction function with MAC addresses
+// of virtualization platforms, categories from the config file and --category
+func TestLookupActionCategory(t *testing.T) {
+	// Create a test CSV database, in memory, with the OUI of VMware
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,005056,"VMware, Inc.",3401 Hillview Avenue PALO ALTO CA US 94304
+MA-L,00000C,"Cisco Systems, Inc",170 WEST TASMAN DRIVE SAN JOSE CA US 95134
+MA-L,00005E,"ICANN, IANA Department",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094`
+
+	input := "00:50:56:01:02:03 52:54:00:12:34:56 02:42:ac:11:00:02 00:00:0c:01:02:03 00:00:5e:00:53:01 00:1a:2b:00:00:01"
+
+	tests := []struct {
+		name       string
+		categories map[string]interface{}
+		only       []string
+		expected   string
+	}{
+		{
+			name:       "BuiltIn",
+			categories: nil,
+			only:       nil,
+			expected: "00:50:56:01:02:03 (VMware, Inc.) [virtual]\n" +
+				"52:54:00:12:34:56 (QEMU/KVM) [virtual]\n" +
+				"02:42:ac:11:00:02 (Docker) [virtual]\n" +
+				"00:00:0c:01:02:03 (Cisco Systems, Inc) [networking]\n" +
+				"00:00:5e:00:53:01 (ICANN, IANA Department)\n" +
+				"00:1a:2b:00:00:01\n",
+		},
+		{
+			name:       "OnlyVirtual",
+			categories: nil,
+			only:       []string{"Virtual"},
+			expected: "00:50:56:01:02:03 (VMware, Inc.) [virtual]\n" +
+				"52:54:00:12:34:56 (QEMU/KVM) [virtual]\n" +
+				"02:42:ac:11:00:02 (Docker) [virtual]\n",
+		},
+		{
+			name:       "OnlyNetworking",
+			categories: nil,
+			only:       []string{"networking", "printer"},
+			expected:   "00:00:0c:01:02:03 (Cisco Systems, Inc) [networking]\n",
+		},
+		{
+			name: "FromConfig",
+			categories: map[string]interface{}{
+				"lab": []interface{}{
+					map[string]interface{}{"prefix": "00:1a:2b:*", "description": "Lab switches"},
+				},
+			},
+			only:     []string{"lab"},
+			expected: "00:1a:2b:00:00:01 (Lab switches) [lab]\n",
+		},
+	}
+
+	viper.Set("lookup.suppress-unmatched", false)
+	viper.Set("lookup.sort-asc", false)
+	viper.Set("lookup.sort-desc", false)
+	defer viper.Set("categories", nil)
+	defer viper.Set("lookup.category", nil)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("categories", test.categories)
+			viper.Set("lookup.category", test.only)
+
+			// Call the function to test
+			var output strings.Builder
+			if err := lookupAction(&output, strings.NewReader(csvData), newStringInput(input)); err != nil {
+				t.Fatalf("error returned from lookupAction(): %v", err)
+			}
+
+			// Check the output
+			if output.String() != test.expected {
+				t.Errorf("lookupAction() output = %q, want %q", output.String(), test.expected)
+			}
+		})
+	}
+}
+
+// TestLookupActionCount tests the lookupAction function with each MAC
+// address printed once with the number of times it was found
+func TestLookupActionCount(t *testing.T) {
+	// Create a test CSV database, in memory
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00005E,"ICANN, IANA Department",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094`
+
+	input := "0000.5e00.5301\n00:1a:2b:00:00:01 00:00:5E:00:53:01\n00-00-5e-00-53-01"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		json     bool
+		csv      bool
+		expected string
+	}{
+		{
+			name: "Text",
+			expected: "      3 0000.5e00.5301 (ICANN, IANA Department) first 1 last 3\n" +
+				"      1 00:1a:2b:00:00:01 first 2 last 2\n",
+		},
+		{
+			name: "CSV",
+			csv:  true,
+			expected: "0000.5e00.5301,\"ICANN, IANA Department\",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094,3,1,3\n" +
+				"00:1a:2b:00:00:01,,,1,2,2\n",
+		},
+		{
+			name: "JSON",
+			json: true,
+			expected: `{"line":1,"count":3,"last_line":3,"mac":"0000.5e00.5301","assignment":"00005E","organization":"ICANN, IANA Department","address":"INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094"}` + "\n" +
+				`{"line":2,"count":1,"last_line":2,"mac":"00:1a:2b:00:00:01","assignment":"001A2B"}` + "\n",
+		},
+	}
+
+	viper.Set("lookup.suppress-unmatched", false)
+	viper.Set("lookup.sort-asc", false)
+	viper.Set("lookup.sort-desc", false)
+	viper.Set("lookup.count", true)
+	defer viper.Set("lookup.count", false)
+	defer viper.Set("lookup.json", false)
+	defer viper.Set("lookup.csv", false)
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("lookup.json", test.json)
+			viper.Set("lookup.csv", test.csv)
+
+			// Call the function to test
+			var output strings.Builder
+			if err := lookupAction(&output, strings.NewReader(csvData), newStringInput(input)); err != nil {
+				t.Fatalf("error returned from lookupAction(): %v", err)
+			}
+
+			// Check the output
+			if output.String() != test.expected {
+				t.Errorf("lookupAction() output = %q, want %q", output.String(), test.expected)
+			}
+		})
+	}
+}
+
+// TestLookupActionSortBy tests the lookupAction function with the output
+// sorted by the --sort-by flag, with MAC addresses in mixed notations
+func TestLookupActionSortBy(t *testing.T) {
+	// Create a test CSV database, in memory
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00000C,"Cisco Systems, Inc",170 WEST TASMAN DRIVE SAN JOSE CA US 95134
+MA-L,00005E,"ICANN, IANA Department",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094`
+
+	input := "00-00-5E-00-53-02 0000.0c00.0001\n" +
+		"00:1a:2b:00:00:01 00:00:5e:00:53:01\n" +
+		"0000.5E00.5302 00:00:0C:00:00:02"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		sortBy   string
+		sortDesc bool
+		count    bool
+		expected string
+		err      bool
+	}{
+		{
+			name:   "Mac",
+			sortBy: "mac",
+			expected: "0000.0c00.0001 (Cisco Systems, Inc) [networking]\n" +
+				"00:00:0C:00:00:02 (Cisco Systems, Inc) [networking]\n" +
+				"00:00:5e:00:53:01 (ICANN, IANA Department)\n" +
+				"00-00-5E-00-53-02 (ICANN, IANA Department)\n" +
+				"0000.5E00.5302 (ICANN, IANA Department)\n" +
+				"00:1a:2b:00:00:01\n",
+		},
+		{
+			name:     "MacDesc",
+			sortBy:   "mac",
+			sortDesc: true,
+			expected: "00:1a:2b:00:00:01\n" +
+				"00-00-5E-00-53-02 (ICANN, IANA Department)\n" +
+				"0000.5E00.5302 (ICANN, IANA Department)\n" +
+				"00:00:5e:00:53:01 (ICANN, IANA Department)\n" +
+				"00:00:0C:00:00:02 (Cisco Systems, Inc) [networking]\n" +
+				"0000.0c00.0001 (Cisco Systems, Inc) [networking]\n",
+		},
+		{
+			name:   "Vendor",
+			sortBy: "vendor",
+			expected: "0000.0c00.0001 (Cisco Systems, Inc) [networking]\n" +
+				"00:00:0C:00:00:02 (Cisco Systems, Inc) [networking]\n" +
+				"00-00-5E-00-53-02 (ICANN, IANA Department)\n" +
+				"00:00:5e:00:53:01 (ICANN, IANA Department)\n" +
+				"0000.5E00.5302 (ICANN, IANA Department)\n" +
+				"00:1a:2b:00:00:01\n",
+		},
+		{
+			name:     "VendorDesc",
+			sortBy:   "vendor",
+			sortDesc: true,
+			expected: "00-00-5E-00-53-02 (ICANN, IANA Department)\n" +
+				"00:00:5e:00:53:01 (ICANN, IANA Department)\n" +
+				"0000.5E00.5302 (ICANN, IANA Department)\n" +
+				"0000.0c00.0001 (Cisco Systems, Inc) [networking]\n" +
+				"00:00:0C:00:00:02 (Cisco Systems, Inc) [networking]\n" +
+				"00:1a:2b:00:00:01\n",
+		},
+		{
+			name:     "CountDesc",
+			sortBy:   "count",
+			sortDesc: true,
+			count:    true,
+			expected: "      2 00-00-5E-00-53-02 (ICANN, IANA Department) first 1 last 3\n" +
+				"      1 0000.0c00.0001 (Cisco Systems, Inc) [networking] first 1 last 1\n" +
+				"      1 00:1a:2b:00:00:01 first 2 last 2\n" +
+				"      1 00:00:5e:00:53:01 (ICANN, IANA Department) first 2 last 2\n" +
+				"      1 00:00:0C:00:00:02 (Cisco Systems, Inc) [networking] first 3 last 3\n",
+		},
+		{
+			name:   "Count",
+			sortBy: "count",
+			expected: "0000.0c00.0001 (Cisco Systems, Inc) [networking]\n" +
+				"00:1a:2b:00:00:01\n" +
+				"00:00:5e:00:53:01 (ICANN, IANA Department)\n" +
+				"00:00:0C:00:00:02 (Cisco Systems, Inc) [networking]\n" +
+				"00-00-5E-00-53-02 (ICANN, IANA Department)\n" +
+				"0000.5E00.5302 (ICANN, IANA Department)\n",
+		},
+		{
+			name:     "PositionDesc",
+			sortBy:   "position",
+			sortDesc: true,
+			expected: "00:00:0C:00:00:02 (Cisco Systems, Inc) [networking]\n" +
+				"0000.5E00.5302 (ICANN, IANA Department)\n" +
+				"00:00:5e:00:53:01 (ICANN, IANA Department)\n" +
+				"00:1a:2b:00:00:01\n" +
+				"0000.0c00.0001 (Cisco Systems, Inc) [networking]\n" +
+				"00-00-5E-00-53-02 (ICANN, IANA Department)\n",
+		},
+		{
+			name:   "Invalid",
+			sortBy: "size",
+			err:    true,
+		},
+	}
+
+	viper.Set("lookup.suppress-unmatched", false)
+	viper.Set("lookup.sort-asc", false)
+	defer viper.Set("lookup.sort-by", "")
+	defer viper.Set("lookup.sort-desc", false)
+	defer viper.Set("lookup.count", false)
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("lookup.sort-by", test.sortBy)
+			viper.Set("lookup.sort-desc", test.sortDesc)
+			viper.Set("lookup.count", test.count)
+
+			// Call the function to test
+			var output strings.Builder
+			err := lookupAction(&output, strings.NewReader(csvData), newStringInput(input))
+			if test.err {
+				if err == nil {
+					t.Errorf("lookupAction() returned no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error returned from lookupAction(): %v", err)
+			}
+
+			// Check the output
+			if output.String() != test.expected {
+				t.Errorf("lookupAction() output =\n%s\nwant\n%s", output.String(), test.expected)
+			}
+		})
+	}
+}
+
+// TestLookupActionCsvWithFilename tests that matched and unmatched MAC
+// addresses are written as CSV rows with the same columns
+func TestLookupActionCsvWithFilename(t *testing.T) {
+	// Create a test CSV database, in memory
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00005E,"ICANN, IANA Department",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094`
+
+	// Create an input file
+	filename := filepath.Join(t.TempDir(), "in.txt")
+	os.WriteFile(filename, []byte("00:00:5e:00:53:01\nnone\n11:22:33:44:55:66 52:54:00:12:34:56\n"), 0644)
+
+	expected := filename + ",1,00:00:5e:00:53:01,\"ICANN, IANA Department\",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094\n" +
+		filename + ",3,11:22:33:44:55:66,,\n" +
+		filename + ",3,52:54:00:12:34:56,QEMU/KVM,\n"
+
+	viper.Set("lookup.suppress-unmatched", false)
+	viper.Set("lookup.sort-asc", false)
+	viper.Set("lookup.sort-desc", false)
+	viper.Set("lookup.csv", true)
+	viper.Set("lookup.with-filename", true)
+	defer viper.Set("lookup.csv", false)
+	defer viper.Set("lookup.with-filename", false)
+
+	// Call the function to test
+	var output strings.Builder
+	if err := lookupAction(&output, strings.NewReader(csvData), cli.NewFileInput([]string{filename})); err != nil {
+		t.Fatalf("error returned from lookupAction(): %v", err)
+	}
+
+	// Check the output
+	if output.String() != expected {
+		t.Errorf("lookupAction() output = %q, want %q", output.String(), expected)
+	}
+
+	// The category is added as the last column if categories are requested
+	viper.Set("lookup.category", []string{"virtual"})
+	defer viper.Set("lookup.category", nil)
+	expected = filename + ",3,52:54:00:12:34:56,QEMU/KVM,,virtual\n"
+	output.Reset()
+	if err := lookupAction(&output, strings.NewReader(csvData), cli.NewFileInput([]string{filename})); err != nil {
+		t.Fatalf("error returned from lookupAction(): %v", err)
+	}
+	if output.String() != expected {
+		t.Errorf("lookupAction() output = %q, want %q", output.String(), expected)
+	}
+}
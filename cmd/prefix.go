@@ -0,0 +1,171 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/debug"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/bitcanon/mactool/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// prefixAction finds the MAC addresses in the input and prints the fewest
+// prefixes that contain exactly those MAC addresses, one per line. If
+// single is set, the longest prefix that contains all of them is printed.
+func prefixAction(out io.Writer, single bool, input cli.Input) error {
+	// Find the MAC addresses with the scanner configured by the flags
+	scanner, err := newScanner("prefix")
+	if err != nil {
+		return err
+	}
+
+	// Collect the MAC addresses in the input
+	var addresses []string
+	err = input.Scan(func(line cli.Line) error {
+		for _, m := range scanner.FindAll(line.Text) {
+			addresses = append(addresses, m.Address())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Nothing to summarize without MAC addresses
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	// Summarize the MAC addresses into prefixes
+	var prefixes []mac.Prefix
+	if single {
+		p, err := mac.Cover(addresses)
+		if err != nil {
+			return err
+		}
+		prefixes = append(prefixes, p)
+	} else {
+		prefixes = mac.Summarize(addresses)
+	}
+
+	// Print the prefixes to the output writer
+	w := bufio.NewWriter(out)
+	for _, p := range prefixes {
+		fmt.Fprintln(w, p)
+	}
+	return w.Flush()
+}
+
+// Example help text for the prefix command
+const prefixExample = `  mactool prefix 00:1a:2b:00:00:00 00:1a:2b:00:00:01 00:1a:2b:00:00:02
+  mactool prefix --single -i inventory.txt
+  mactool extract -i arp.txt --match-prefix 00:1a:2b:* | mactool prefix
+
+Interactive mode:
+  mactool prefix`
+
+// Long help text for the prefix command
+const prefixLong = `Summarize MAC addresses into prefixes
+
+The MAC addresses are found in the input like the extract command
+does, and summarized into the fewest prefixes that contain exactly
+those MAC addresses, written in CIDR style (00:1a:2b:00:00:00/28).
+With --single, the longest prefix that contains all of the MAC
+addresses is printed instead, which may contain other addresses.
+
+Prefixes can be used to filter the MAC addresses found by the extract
+and lookup commands with --match-prefix.`
+
+// prefixCmd represents the prefix command
+var prefixCmd = &cobra.Command{
+	Use:          "prefix [input]",
+	Short:        "Summarize MAC addresses into prefixes",
+	Long:         prefixLong,
+	Example:      prefixExample,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Open the input from file, pipe, arguments or interactive mode
+		input, err := openInput("prefix", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		// Determine the output file using Viper
+		outputFile := viper.GetString("prefix.output-file")
+		append := viper.GetBool("prefix.append")
+
+		// Get the output stream
+		outStream, err := utils.GetOutputStream(outputFile, append)
+		if err != nil {
+			return err
+		}
+		defer outStream.Close()
+
+		// Print the configuration debug if the --debug flag is set
+		if viper.GetBool("debug") {
+			debug.PrintConfigDebug()
+		}
+
+		// Summarize the MAC addresses in the input
+		return prefixAction(outStream, viper.GetBool("prefix.single"), input)
+	},
+}
+
+func init() {
+	// Add the prefix command to the root command
+	rootCmd.AddCommand(prefixCmd)
+
+	// Set to the value of the --single flag if set
+	prefixCmd.Flags().Bool("single", false, "print the longest prefix that contains all of the MAC addresses")
+	viper.BindPFlag("prefix.single", prefixCmd.Flags().Lookup("single"))
+
+	// Add flag for input file paths, which may be repeated
+	prefixCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
+	viper.BindPFlag("prefix.input-file", prefixCmd.Flags().Lookup("input-file"))
+
+	// Set to the value of the --recursive flag if set
+	prefixCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("prefix.recursive", prefixCmd.Flags().Lookup("recursive"))
+
+	// Set to the value of the --loose flag if set
+	prefixCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("prefix.loose", prefixCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	prefixCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("prefix.accept", prefixCmd.Flags().Lookup("accept"))
+
+	// Add flag for output file path
+	prefixCmd.Flags().StringP("output-file", "o", "", "write output to file")
+	viper.BindPFlag("prefix.output-file", prefixCmd.Flags().Lookup("output-file"))
+
+	// Set to the value of the --append flag if set
+	prefixCmd.Flags().BoolP("append", "a", false, "append when writing to file with --output-file")
+	viper.BindPFlag("prefix.append", prefixCmd.Flags().Lookup("append"))
+}
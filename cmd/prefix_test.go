@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestPrefixAction tests the prefixAction function
+func TestPrefixAction(t *testing.T) {
+	input := "00:1a:2b:00:00:00 00:1a:2b:00:00:01\n001A.2B00.0002 00-1a-2b-00-00-03 00:1a:2b:00:00:09"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		input    string
+		single   bool
+		expected string
+		err      bool
+	}{
+		{
+			name:     "Summarize",
+			input:    input,
+			expected: "00:1a:2b:00:00:00/46\n00:1a:2b:00:00:09/48\n",
+		},
+		{
+			name:     "Single",
+			input:    input,
+			single:   true,
+			expected: "00:1a:2b:00:00:00/44\n",
+		},
+		{
+			name:     "NoAddresses",
+			input:    "no MAC addresses here",
+			expected: "",
+		},
+		{
+			name:   "SingleMixedLengths",
+			input:  "00:1a:2b:00:00:00 02:00:5e:10:00:00:00:01",
+			single: true,
+			err:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Call the function to test
+			var output bytes.Buffer
+			err := prefixAction(&output, test.single, newStringInput(test.input))
+			if test.err {
+				if err == nil {
+					t.Errorf("expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error returned from prefixAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bitcanon/mactool/mac"
+	"github.com/spf13/viper"
+)
+
+// Format profiles are named formats defined in the config file, such as:
+//
+//	profiles:
+//	  cisco:
+//	    case: lower
+//	    delimiter: "."
+//	    group-size: 4
+//	  inventory:
+//	    template: "{OCTETS::}"
+//
+// A profile sets either the case, delimiter and group size, or a template
+// or preset name, which may be reversed with reverse and bit-reverse.
+
+// profileKeys are the settings a format profile may contain
+var profileKeys = []string{"case", "delimiter", "group-size", "template", "reverse", "bit-reverse"}
+
+// profileNames returns the names of the profiles in the config file, sorted
+func profileNames() []string {
+	var names []string
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateProfiles checks that all of the profiles in the config
+// file are valid, so that mistakes are reported at startup
+func validateProfiles() error {
+	for _, name := range profileNames() {
+		if _, err := loadProfile(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadProfile returns the formatter of the named profile in the config file
+func loadProfile(name string) (mac.Formatter, error) {
+	// Find the profile in the config file
+	settings, ok := viper.GetStringMap("profiles")[strings.ToLower(name)]
+	if !ok {
+		names := profileNames()
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown profile %q; no profiles are defined in the config file", name)
+		}
+		return nil, fmt.Errorf("unknown profile %q; must be one of %s", name, strings.Join(names, ", "))
+	}
+	values, ok := settings.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("profile %q: must be a map of settings (%s)", name, strings.Join(profileKeys, ", "))
+	}
+
+	// Parse the profile and add the name of the profile to any error
+	formatter, err := parseProfile(values)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	return formatter, nil
+}
+
+// parseProfile returns the formatter described by the settings of a profile
+func parseProfile(values map[string]interface{}) (mac.Formatter, error) {
+	// Reject settings that are not known, since they are likely typos
+	for key := range values {
+		if !containsString(profileKeys, key) {
+			return nil, fmt.Errorf("unknown setting %q; must be one of %s", key, strings.Join(profileKeys, ", "))
+		}
+	}
+
+	// Read the byte and bit order settings
+	reverse, err := profileBool(values, "reverse")
+	if err != nil {
+		return nil, err
+	}
+	bitReverse, err := profileBool(values, "bit-reverse")
+	if err != nil {
+		return nil, err
+	}
+
+	// A profile with a template uses the template or preset
+	if template, ok := profileString(values, "template"); ok {
+		for _, key := range []string{"case", "delimiter", "group-size"} {
+			if _, ok := values[key]; ok {
+				return nil, fmt.Errorf("template cannot be combined with %s", key)
+			}
+		}
+		return parseTemplateOrPreset(template, reverse, bitReverse)
+	}
+	if reverse || bitReverse {
+		return nil, fmt.Errorf("reverse and bit-reverse require a template")
+	}
+
+	// Read the case setting
+	format := mac.MacFormat{}
+	if caseName, ok := profileString(values, "case"); ok {
+		switch strings.ToLower(caseName) {
+		case "upper":
+			format.Case = mac.Upper
+		case "lower":
+			format.Case = mac.Lower
+		case "original":
+			format.Case = mac.OriginalCase
+		default:
+			return nil, fmt.Errorf("invalid case %q; must be upper, lower or original", caseName)
+		}
+	}
+
+	// Read the delimiter setting, where an empty delimiter means none
+	if delimiter, ok := profileString(values, "delimiter"); ok {
+		switch delimiter {
+		case ":":
+			format.Delimiter = mac.Colon
+		case "-":
+			format.Delimiter = mac.Hyphen
+		case ".":
+			format.Delimiter = mac.Dot
+		case "", "none":
+			format.Delimiter = mac.None
+		case "original":
+			format.Delimiter = mac.OriginalDelim
+		default:
+			return nil, fmt.Errorf("invalid delimiter %q; must be \":\", \"-\", \".\", none or original", delimiter)
+		}
+	}
+
+	// Read the group size setting
+	if groupSize, ok := profileString(values, "group-size"); ok {
+		switch size, _ := strconv.Atoi(groupSize); size {
+		case 2:
+			format.GroupSize = mac.GroupSizeTwo
+		case 4:
+			format.GroupSize = mac.GroupSizeFour
+		case 6:
+			format.GroupSize = mac.GroupSizeSix
+		default:
+			return nil, fmt.Errorf("invalid group-size %q; must be 2, 4 or 6", groupSize)
+		}
+	}
+
+	return format, nil
+}
+
+// profileString returns the setting of a profile as a string, and whether
+// it is set. A setting without a value in the config file is empty.
+func profileString(values map[string]interface{}, key string) (string, bool) {
+	value, ok := values[key]
+	if !ok {
+		return "", false
+	}
+	if value == nil {
+		return "", true
+	}
+	return fmt.Sprint(value), true
+}
+
+// profileBool returns the boolean setting of a profile, false if it is not set
+func profileBool(values map[string]interface{}, key string) (bool, error) {
+	value, ok := profileString(values, key)
+	if !ok {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q; must be true or false", key, value)
+	}
+	return b, nil
+}
+
+// parseTemplateOrPreset returns the template for a pattern with placeholders,
+// or the preset with the name, reversed as requested
+func parseTemplateOrPreset(template string, reverse bool, bitReverse bool) (mac.Formatter, error) {
+	if strings.Contains(template, "{") {
+		return mac.ParseTemplate(template, reverse, bitReverse)
+	}
+	preset, err := mac.Preset(template)
+	if err != nil {
+		return nil, err
+	}
+	preset.Reverse = preset.Reverse || reverse
+	preset.BitReverse = preset.BitReverse || bitReverse
+	return preset, nil
+}
+
+// containsString reports whether the list contains the string
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestLoadProfile tests the loadProfile function with
+// valid and invalid profiles from the config file
+func TestLoadProfile(t *testing.T) {
+	viper.Set("profiles", map[string]interface{}{
+		"cisco":     map[string]interface{}{"case": "lower", "delimiter": ".", "group-size": 4},
+		"bare":      map[string]interface{}{"case": "upper", "delimiter": nil},
+		"inventory": map[string]interface{}{"template": "{OCTETS::}", "reverse": true},
+		"windows":   map[string]interface{}{"template": "windows"},
+		"typo":      map[string]interface{}{"delimeter": ":"},
+		"mixed":     map[string]interface{}{"template": "windows", "case": "lower"},
+		"reversed":  map[string]interface{}{"reverse": "yes please"},
+		"size":      map[string]interface{}{"group-size": 3},
+		"scalar":    "cisco",
+	})
+	defer viper.Set("profiles", nil)
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		profile  string
+		expected string
+		err      string
+	}{
+		{name: "GroupSize", profile: "cisco", expected: "001a.2b3c.4d5e"},
+		{name: "NameIsCaseInsensitive", profile: "Cisco", expected: "001a.2b3c.4d5e"},
+		{name: "EmptyDelimiter", profile: "bare", expected: "001A2B3C4D5E"},
+		{name: "Template", profile: "inventory", expected: "5E:4D:3C:2B:1A:00"},
+		{name: "Preset", profile: "windows", expected: "00-1A-2B-3C-4D-5E"},
+		{name: "Unknown", profile: "juniper", err: `unknown profile "juniper"; must be one of bare, cisco,`},
+		{name: "UnknownSetting", profile: "typo", err: `profile "typo": unknown setting "delimeter"`},
+		{name: "TemplateWithCase", profile: "mixed", err: `profile "mixed": template cannot be combined with case`},
+		{name: "InvalidBool", profile: "reversed", err: `profile "reversed": invalid reverse "yes please"`},
+		{name: "InvalidGroupSize", profile: "size", err: `profile "size": invalid group-size "3"`},
+		{name: "NotAMap", profile: "scalar", err: `profile "scalar": must be a map of settings`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Call the function to test
+			format, err := loadProfile(test.profile)
+			if test.err != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+					t.Fatalf("expected error starting with %q, but got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error returned from loadProfile(): %v", err)
+			}
+
+			// Format a MAC address with the profile
+			result, err := format.Format("00:1a:2b:3c:4d:5e")
+			if err != nil {
+				t.Fatalf("error returned from Format(): %v", err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, result)
+			}
+		})
+	}
+
+	// Validating all of the profiles reports the first invalid one
+	if err := validateProfiles(); err == nil || !strings.Contains(err.Error(), `profile "mixed"`) {
+		t.Errorf("expected error for profile \"mixed\" from validateProfiles(), but got %v", err)
+	}
+}
+
+// TestLookupActionProfile tests the lookupAction function with
+// the MAC addresses printed in the format of a profile, as JSON
+func TestLookupActionProfile(t *testing.T) {
+	viper.Set("profiles", map[string]interface{}{
+		"cisco": map[string]interface{}{"case": "lower", "delimiter": ".", "group-size": 4},
+	})
+	viper.Set("lookup.profile", "cisco")
+	viper.Set("lookup.json", true)
+	viper.Set("lookup.suppress-unmatched", false)
+	defer viper.Set("profiles", nil)
+	defer viper.Set("lookup.profile", "")
+	defer viper.Set("lookup.json", false)
+
+	// Create a test CSV database, in memory
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00005E,"Banana, Inc.",1 Infinite Loop Cupocoffee CA US 12345`
+
+	// Call the function to test
+	var output strings.Builder
+	input := "Known 00:00:5E:00:53:01, unknown 99-99-99-00-53-02"
+	if err := lookupAction(&output, strings.NewReader(csvData), newStringInput(input)); err != nil {
+		t.Fatalf("error returned from lookupAction(): %v", err)
+	}
+
+	// Compare the results to the expected values
+	expected := `{"mac":"0000.5e00.5301","assignment":"00005E","organization":"Banana, Inc.","address":"1 Infinite Loop Cupocoffee CA US 12345"}` + "\n" +
+		`{"mac":"9999.9900.5302","assignment":"999999"}` + "\n"
+	if output.String() != expected {
+		t.Errorf("expected %q, but got %q", expected, output.String())
+	}
+}
+
+// TestCreateFormatterFromFlagsProfile tests that the profile of each
+// command that formats MAC addresses replaces its format flags
+func TestCreateFormatterFromFlagsProfile(t *testing.T) {
+	viper.Set("profiles", map[string]interface{}{
+		"cisco": map[string]interface{}{"case": "lower", "delimiter": ".", "group-size": 4},
+	})
+	defer viper.Set("profiles", nil)
+
+	for _, command := range []string{"format", "range", "lookup"} {
+		t.Run(command, func(t *testing.T) {
+			// Set the flags
+			viper.Set(command+".profile", "cisco")
+			viper.Set(command+".upper", true)
+			defer viper.Set(command+".profile", "")
+			defer viper.Set(command+".upper", false)
+
+			// Create the formatter and format a MAC address with it
+			format, err := createFormatterFromFlags(command)
+			if err != nil {
+				t.Fatalf("error returned from createFormatterFromFlags(): %v", err)
+			}
+			actual, err := format.Format("00:00:5E:00:53:01")
+			if err != nil {
+				t.Fatalf("error returned from Format(): %v", err)
+			}
+			if actual != "0000.5e00.5301" {
+				t.Errorf("expected 0000.5e00.5301, but got %q", actual)
+			}
+		})
+	}
+}
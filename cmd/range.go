@@ -0,0 +1,250 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/debug"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/bitcanon/mactool/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Errors returned when parsing a range of MAC addresses
+var errInvalidRange = errors.New("invalid range; must be start-end or start+count")
+var errReversedRange = errors.New("invalid range; the end is below the start")
+
+// parseRange parses a range of MAC addresses, written as start-end or
+// start+count, and returns the first MAC address and the number of MAC
+// addresses in the range. Since MAC addresses may contain hyphens, the
+// range is split at the hyphen where both sides are valid MAC addresses.
+func parseRange(spec string) (string, int64, error) {
+	// A start address and the number of addresses
+	if i := strings.LastIndex(spec, "+"); i > 0 {
+		start := spec[:i]
+		if err := mac.Validate(start); err != nil {
+			return "", 0, fmt.Errorf("%w: %s: %v", errInvalidRange, spec, err)
+		}
+		count, err := strconv.ParseInt(spec[i+1:], 10, 64)
+		if err != nil || count < 1 {
+			return "", 0, fmt.Errorf("%w: %s: count must be a positive number", errInvalidRange, spec)
+		}
+		return start, count, nil
+	}
+
+	// A start and end address
+	for i := strings.Index(spec, "-"); i > 0; i = nextIndex(spec, "-", i) {
+		start, end := spec[:i], spec[i+1:]
+		if mac.Validate(start) != nil || mac.Validate(end) != nil {
+			continue
+		}
+		difference, err := mac.Difference(start, end)
+		if err != nil {
+			return "", 0, fmt.Errorf("%w: %s: %v", errInvalidRange, spec, err)
+		}
+		if difference < 0 {
+			return "", 0, fmt.Errorf("%w: %s", errReversedRange, spec)
+		}
+		if difference == math.MaxInt64 {
+			return "", 0, fmt.Errorf("%w: %s: %v", errInvalidRange, spec, mac.ErrAddressOverflow)
+		}
+		return start, difference + 1, nil
+	}
+	return "", 0, fmt.Errorf("%w: %s", errInvalidRange, spec)
+}
+
+// nextIndex returns the index of the next substring after the index i,
+// or -1 if there is none
+func nextIndex(s string, substr string, i int) int {
+	next := strings.Index(s[i+1:], substr)
+	if next < 0 {
+		return -1
+	}
+	return i + 1 + next
+}
+
+// rangeAction expands each of the ranges of MAC addresses in the input,
+// separated by whitespace, and prints the MAC addresses in the ranges
+// in the format, one per line. Unless crossOui is set, all of the MAC
+// addresses in a range must be within the OUI of the first one.
+func rangeAction(out io.Writer, format mac.Formatter, crossOui bool, input cli.Input) error {
+	// Buffer the output since it is written one address at a time
+	w := bufio.NewWriter(out)
+
+	// Process the input one line at a time
+	err := input.Scan(func(line cli.Line) error {
+		for _, spec := range strings.Fields(line.Text) {
+			// Parse the range
+			start, count, err := parseRange(spec)
+			if err != nil {
+				return err
+			}
+
+			// Check that the last MAC address is within the range of MAC
+			// addresses, and within the OUI unless it may be crossed
+			add := mac.AddWithinOui
+			if crossOui {
+				add = mac.Add
+			}
+			if _, err := add(start, count-1); err != nil {
+				return fmt.Errorf("%s: %w", spec, err)
+			}
+
+			// Print the MAC addresses in the range
+			for i := int64(0); i < count; i++ {
+				address, err := mac.Add(start, i)
+				if err != nil {
+					return err
+				}
+				formatted, err := format.Format(address)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(w, formatted)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write any buffered output to the output writer
+	return w.Flush()
+}
+
+// Example help text for the range command
+const rangeExample = `  mactool range 00:00:5e:00:53:00+16
+  mactool range 00-00-5E-00-53-00-00-00-5E-00-53-0F --lower --delimiter :
+  mactool range 0000.5e00.53f0+32 --cross-oui
+  mactool range -i blocks.txt -p cisco
+
+Interactive mode:
+  mactool range`
+
+// Long help text for the range command
+const rangeLong = `Expand ranges of MAC addresses into the addresses in them
+
+A range is written as start-end, which includes both the start and
+end address, or start+count, which is count addresses beginning with
+the start address. The ranges are separated by whitespace.
+
+The MAC addresses are printed in the notation of the start address,
+or in the format given by the flags, like the format command. All of
+the MAC addresses in a range must be within the OUI of the start
+address, unless --cross-oui is set.`
+
+// rangeCmd represents the range command
+var rangeCmd = &cobra.Command{
+	Use:          "range [start-end | start+count...]",
+	Short:        "Expand ranges of MAC addresses",
+	Long:         rangeLong,
+	Example:      rangeExample,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create the formatter from the profile or format flags
+		format, err := createFormatterFromFlags("range")
+		if err != nil {
+			return err
+		}
+
+		// Open the input from file, pipe, arguments or interactive mode
+		input, err := openInput("range", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		// Determine the output file using Viper
+		outputFile := viper.GetString("range.output-file")
+		append := viper.GetBool("range.append")
+
+		// Get the output stream
+		outStream, err := utils.GetOutputStream(outputFile, append)
+		if err != nil {
+			return err
+		}
+		defer outStream.Close()
+
+		// Print the configuration debug if the --debug flag is set
+		if viper.GetBool("debug") {
+			debug.PrintConfigDebug()
+		}
+
+		// Expand the ranges in the input
+		return rangeAction(outStream, format, viper.GetBool("range.cross-oui"), input)
+	},
+}
+
+func init() {
+	// Add the range command to the root command
+	rootCmd.AddCommand(rangeCmd)
+
+	// Add the --upper flag to the range command
+	rangeCmd.Flags().BoolP("upper", "u", false, "convert MAC addresses to upper case")
+	viper.BindPFlag("range.upper", rangeCmd.Flags().Lookup("upper"))
+
+	// Add the --lower flag to the range command
+	rangeCmd.Flags().BoolP("lower", "l", false, "convert MAC addresses to lower case")
+	viper.BindPFlag("range.lower", rangeCmd.Flags().Lookup("lower"))
+
+	// Add the --delimiter flag to the range command
+	rangeCmd.Flags().StringP("delimiter", "d", "=", "delimiter character to use between hex groups")
+	viper.BindPFlag("range.delimiter", rangeCmd.Flags().Lookup("delimiter"))
+
+	// Add the --group-size flag to the range command
+	rangeCmd.Flags().IntP("group-size", "g", 0, "number of characters in each hex group")
+	viper.BindPFlag("range.group-size", rangeCmd.Flags().Lookup("group-size"))
+
+	// Add the --profile flag to the range command
+	rangeCmd.Flags().StringP("profile", "p", "", "format profile from the config file, overrides -u, -l, -d and -g")
+	viper.BindPFlag("range.profile", rangeCmd.Flags().Lookup("profile"))
+
+	// Set to the value of the --cross-oui flag if set
+	rangeCmd.Flags().Bool("cross-oui", false, "allow ranges that continue past the OUI of the start address")
+	viper.BindPFlag("range.cross-oui", rangeCmd.Flags().Lookup("cross-oui"))
+
+	// Add flag for input file paths, which may be repeated
+	rangeCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
+	viper.BindPFlag("range.input-file", rangeCmd.Flags().Lookup("input-file"))
+
+	// Set to the value of the --recursive flag if set
+	rangeCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("range.recursive", rangeCmd.Flags().Lookup("recursive"))
+
+	// Add flag for output file path
+	rangeCmd.Flags().StringP("output-file", "o", "", "write output to file")
+	viper.BindPFlag("range.output-file", rangeCmd.Flags().Lookup("output-file"))
+
+	// Set to the value of the --append flag if set
+	rangeCmd.Flags().BoolP("append", "a", false, "append when writing to file with --output-file")
+	viper.BindPFlag("range.append", rangeCmd.Flags().Lookup("append"))
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bitcanon/mactool/mac"
+)
+
+// TestRangeAction tests the rangeAction function
+func TestRangeAction(t *testing.T) {
+	original := mac.MacFormat{Case: mac.OriginalCase, Delimiter: mac.OriginalDelim, GroupSize: mac.OriginalGroupSize}
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		input    string
+		format   mac.Formatter
+		crossOui bool
+		expected string
+		err      error
+	}{
+		{
+			name:     "Count",
+			input:    "00:00:5e:00:53:fe+3",
+			format:   original,
+			expected: "00:00:5e:00:53:fe\n00:00:5e:00:53:ff\n00:00:5e:00:54:00\n",
+		},
+		{
+			name:     "StartEndWithHyphens",
+			input:    "00-00-5E-00-53-0E-00-00-5E-00-53-10",
+			format:   original,
+			expected: "00-00-5E-00-53-0E\n00-00-5E-00-53-0F\n00-00-5E-00-53-10\n",
+		},
+		{
+			name:     "SeveralRangesFormatted",
+			input:    "0000.5e00.5301+1 00:00:5e:00:53:10-00:00:5e:00:53:11",
+			format:   mac.MacFormat{Case: mac.Upper, Delimiter: mac.Dot, GroupSize: mac.GroupSizeFour},
+			expected: "0000.5E00.5301\n0000.5E00.5310\n0000.5E00.5311\n",
+		},
+		{
+			name:     "CrossOui",
+			input:    "00:00:5e:ff:ff:ff+2",
+			format:   original,
+			crossOui: true,
+			expected: "00:00:5e:ff:ff:ff\n00:00:5f:00:00:00\n",
+		},
+		{
+			name:   "OutsideOui",
+			input:  "00:00:5e:ff:ff:ff+2",
+			format: original,
+			err:    mac.ErrOuiOverflow,
+		},
+		{
+			name:   "Overflow",
+			input:  "ff:ff:ff:ff:ff:ff+2",
+			format: original,
+			err:    mac.ErrAddressOverflow,
+		},
+		{
+			name:   "Reversed",
+			input:  "00:00:5e:00:53:10-00:00:5e:00:53:01",
+			format: original,
+			err:    errReversedRange,
+		},
+		{
+			name:   "ZeroCount",
+			input:  "00:00:5e:00:53:10+0",
+			format: original,
+			err:    errInvalidRange,
+		},
+		{
+			name:   "NotARange",
+			input:  "00:00:5e:00:53:10",
+			format: original,
+			err:    errInvalidRange,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Call the function to test
+			var output bytes.Buffer
+			err := rangeAction(&output, test.format, test.crossOui, newStringInput(test.input))
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
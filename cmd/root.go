@@ -49,6 +49,12 @@ GitHub: https://github.com/bitcanon/mactool
 	CompletionOptions: cobra.CompletionOptions{
 		DisableDefaultCmd: true,
 	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Report mistakes in the format profiles of the config file
+		// before running any command. Mistakes in the categories are
+		// reported by the commands that use them.
+		return validateProfiles()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -0,0 +1,421 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/debug"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/bitcanon/mactool/oui"
+	"github.com/bitcanon/mactool/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// unknownGroup is the name of the group of MAC addresses
+// without a vendor, registry or category
+const unknownGroup = "unknown"
+
+// statsCount is the number of MAC addresses in a group,
+// such as the MAC addresses of a vendor
+type statsCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// statsReport is the statistics of the MAC addresses in the input
+type statsReport struct {
+	Total      int          `json:"total"`
+	Unique     int          `json:"unique"`
+	Unicast    int          `json:"unicast"`
+	Multicast  int          `json:"multicast"`
+	Universal  int          `json:"uaa"`
+	Local      int          `json:"laa"`
+	Vendors    []statsCount `json:"vendors"`
+	Special    []statsCount `json:"special"`
+	Registries []statsCount `json:"registries"`
+	Categories []statsCount `json:"categories"`
+}
+
+// statsCounter counts the MAC addresses in the groups of a report
+type statsCounter struct {
+	report     statsReport
+	vendors    map[string]int
+	special    map[string]int
+	registries map[string]int
+	categories map[string]int
+}
+
+// add counts the MAC address with its vendor, which may be nil, and category.
+// Well-known addresses, such as broadcast and LLDP, are counted by their
+// description instead of by vendor.
+func (c *statsCounter) add(macAddress string, vendor *oui.Oui, special bool, category string) error {
+	// Count the group and administration bits of the first octet
+	multicast, err := mac.IsMulticast(macAddress)
+	if err != nil {
+		return err
+	}
+	local, err := mac.IsLocallyAdministered(macAddress)
+	if err != nil {
+		return err
+	}
+	if multicast {
+		c.report.Multicast++
+	} else {
+		c.report.Unicast++
+	}
+	if local {
+		c.report.Local++
+	} else {
+		c.report.Universal++
+	}
+
+	// Count the vendor, registry and category, or unknown if not found
+	name, registry := unknownGroup, unknownGroup
+	if vendor != nil {
+		name = vendor.Organization
+		if vendor.Registry != "" {
+			registry = vendor.Registry
+		}
+	}
+	if category == "" {
+		category = unknownGroup
+	}
+	if special {
+		c.special[name]++
+	} else {
+		c.vendors[name]++
+	}
+	c.registries[registry]++
+	c.categories[category]++
+	return nil
+}
+
+// sortedCounts returns the counts of the groups, the largest first and
+// groups of equal size by name. If top is positive, only the top groups
+// are returned.
+func sortedCounts(groups map[string]int, top int) []statsCount {
+	counts := make([]statsCount, 0, len(groups))
+	for name, count := range groups {
+		counts = append(counts, statsCount{Name: name, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	if top > 0 && len(counts) > top {
+		counts = counts[:top]
+	}
+	return counts
+}
+
+// statsAction finds the MAC addresses in the input, looks up their vendors
+// and prints the number of MAC addresses in total and by vendor, registry,
+// category, unicast/multicast and UAA/LAA, in text, CSV or JSON format
+func statsAction(out io.Writer, ouiCsvFile io.Reader, input cli.Input) error {
+	// Load the OUI database into memory
+	db, err := oui.LoadDatabase(ouiCsvFile)
+	if err != nil {
+		return err
+	}
+
+	// Load the categories, such as virtual, extended by the config file
+	categories, err := loadCategories()
+	if err != nil {
+		return err
+	}
+
+	// Find the MAC addresses with the scanner configured by the flags
+	scanner, err := newScanner("stats")
+	if err != nil {
+		return err
+	}
+
+	// Only keep the MAC addresses in the prefixes of the --match-prefix flag
+	prefixes, err := newPrefixFilter("stats")
+	if err != nil {
+		return err
+	}
+
+	// Count each MAC address once in the groups if the --unique flag is set
+	unique := viper.GetBool("stats.unique")
+	seen := seenAddresses{}
+
+	counter := statsCounter{vendors: map[string]int{}, special: map[string]int{}, registries: map[string]int{}, categories: map[string]int{}}
+	err = input.Scan(func(line cli.Line) error {
+		found := scanner.FindAll(line.Text)
+		if prefixes != nil {
+			found = prefixes.filter(found)
+		}
+		counter.report.Total += len(found)
+
+		// Count the MAC addresses not seen before as unique
+		unseen := seen.filterNew(found)
+		counter.report.Unique += len(unseen)
+		if unique {
+			found = unseen
+		}
+
+		for _, m := range found {
+			macAddress := m.Address()
+			vendor, _, special, err := findVendor(db, categories, macAddress)
+			if err != nil {
+				return err
+			}
+			category, _ := categories.Classify(macAddress, vendor)
+			if err := counter.add(macAddress, vendor, special, category); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sort the groups by size, keeping the top vendors only
+	report := counter.report
+	report.Vendors = sortedCounts(counter.vendors, viper.GetInt("stats.top"))
+	report.Special = sortedCounts(counter.special, 0)
+	report.Registries = sortedCounts(counter.registries, 0)
+	report.Categories = sortedCounts(counter.categories, 0)
+
+	// Write the report in the format of the flags
+	w := bufio.NewWriter(out)
+	switch {
+	case viper.GetBool("stats.json"):
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			return err
+		}
+	case viper.GetBool("stats.csv"):
+		if err := report.writeCSV(w); err != nil {
+			return err
+		}
+	default:
+		report.print(w)
+	}
+	return w.Flush()
+}
+
+// print writes the report to the output writer as text
+func (report statsReport) print(out io.Writer) {
+	fmt.Fprintf(out, "Total:      %d\n", report.Total)
+	fmt.Fprintf(out, "Unique:     %d\n", report.Unique)
+	fmt.Fprintf(out, "Unicast:    %d\n", report.Unicast)
+	fmt.Fprintf(out, "Multicast:  %d\n", report.Multicast)
+	fmt.Fprintf(out, "UAA:        %d\n", report.Universal)
+	fmt.Fprintf(out, "LAA:        %d\n", report.Local)
+
+	// Print the groups below a heading, one per line with the count first
+	sections := []struct {
+		heading string
+		counts  []statsCount
+	}{
+		{"Vendors", report.Vendors},
+		{"Special addresses", report.Special},
+		{"Registries", report.Registries},
+		{"Categories", report.Categories},
+	}
+	for _, section := range sections {
+		// Leave out the special addresses if there are none
+		if len(section.counts) == 0 && section.heading == "Special addresses" {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s:\n", section.heading)
+		for _, c := range section.counts {
+			fmt.Fprintf(out, "%7d  %s\n", c.Count, c.Name)
+		}
+	}
+}
+
+// writeCSV writes the report to the output writer in CSV format, with the
+// columns section, name and count, as in vendor,"Cisco Systems, Inc",5
+func (report statsReport) writeCSV(out io.Writer) error {
+	rows := [][]string{
+		{"section", "name", "count"},
+		{"total", "", strconv.Itoa(report.Total)},
+		{"unique", "", strconv.Itoa(report.Unique)},
+		{"type", "unicast", strconv.Itoa(report.Unicast)},
+		{"type", "multicast", strconv.Itoa(report.Multicast)},
+		{"administration", "uaa", strconv.Itoa(report.Universal)},
+		{"administration", "laa", strconv.Itoa(report.Local)},
+	}
+	for _, c := range report.Vendors {
+		rows = append(rows, []string{"vendor", c.Name, strconv.Itoa(c.Count)})
+	}
+	for _, c := range report.Special {
+		rows = append(rows, []string{"special", c.Name, strconv.Itoa(c.Count)})
+	}
+	for _, c := range report.Registries {
+		rows = append(rows, []string{"registry", c.Name, strconv.Itoa(c.Count)})
+	}
+	for _, c := range report.Categories {
+		rows = append(rows, []string{"category", c.Name, strconv.Itoa(c.Count)})
+	}
+
+	for _, row := range rows {
+		csvRow, err := utils.ConvertStringSliceToCSV(row)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, csvRow)
+	}
+	return nil
+}
+
+// Example help text for the stats command
+const statsExample = `  mactool stats -i arp-table.txt
+  show mac address-table | mactool stats --top 5
+  mactool stats --unique --json -r -i logs/
+  mactool stats --csv -i dhcpd.log -o stats.csv
+
+Interactive mode:
+  mactool stats`
+
+// Long help text for the stats command
+const statsLong = `Print statistics of the MAC addresses in the input
+
+The MAC addresses found in the input are counted in total and unique,
+as unicast and multicast, as universally (UAA) and locally (LAA)
+administered, and by vendor, registry and category. The vendors are
+listed with the most common first. Well-known addresses, such as
+broadcast, LLDP and multicast, are counted by their description
+instead of by vendor.
+
+MAC addresses found more than once are counted each time they are
+found, or only once with --unique. The report is written as text,
+CSV or JSON.`
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:          "stats [input]",
+	Short:        "Print statistics of the MAC addresses in the input",
+	Long:         statsLong,
+	Example:      statsExample,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get the OUI database file, shared with the lookup command
+		csv := viper.GetString("stats.oui-file")
+		if csv == "" {
+			csv = viper.GetString("lookup.oui-file")
+		}
+
+		// Check if the CSV file exists and download it if it doesn't
+		oui.UpdateDatabase(csv)
+
+		// Open the CSV file
+		file, err := os.Open(csv)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		// Open the input from file, pipe, arguments or interactive mode
+		input, err := openInput("stats", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		// Determine the output file using Viper
+		outputFile := viper.GetString("stats.output-file")
+		append := viper.GetBool("stats.append")
+
+		// Get the output stream
+		outStream, err := utils.GetOutputStream(outputFile, append)
+		if err != nil {
+			return err
+		}
+		defer outStream.Close()
+
+		// Print the configuration debug if the --debug flag is set
+		if viper.GetBool("debug") {
+			debug.PrintConfigDebug()
+		}
+
+		// Count the MAC addresses in the input
+		return statsAction(outStream, file, input)
+	},
+}
+
+func init() {
+	// Add the stats command to the root command
+	rootCmd.AddCommand(statsCmd)
+
+	// Set to the value of the --oui-file flag if set
+	statsCmd.Flags().StringP("oui-file", "O", "", "path to OUI CSV file (default is the OUI file of the lookup command)")
+	viper.BindPFlag("stats.oui-file", statsCmd.Flags().Lookup("oui-file"))
+
+	// Set to the value of the --top flag if set
+	statsCmd.Flags().IntP("top", "t", 10, "list only the top N vendors (0 lists all)")
+	viper.BindPFlag("stats.top", statsCmd.Flags().Lookup("top"))
+
+	// Set to the value of the --unique flag if set
+	statsCmd.Flags().BoolP("unique", "U", false, "count each MAC address only once by vendor, registry and category")
+	viper.BindPFlag("stats.unique", statsCmd.Flags().Lookup("unique"))
+
+	// Set to the value of the --json flag if set
+	statsCmd.Flags().BoolP("json", "j", false, "write output in JSON format")
+	viper.BindPFlag("stats.json", statsCmd.Flags().Lookup("json"))
+
+	// Set to the value of the --csv flag if set
+	statsCmd.Flags().BoolP("csv", "c", false, "write output in CSV format")
+	viper.BindPFlag("stats.csv", statsCmd.Flags().Lookup("csv"))
+
+	// Add flag for input file paths, which may be repeated
+	statsCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
+	viper.BindPFlag("stats.input-file", statsCmd.Flags().Lookup("input-file"))
+
+	// Set to the value of the --recursive flag if set
+	statsCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("stats.recursive", statsCmd.Flags().Lookup("recursive"))
+
+	// Set to the value of the --loose flag if set
+	statsCmd.Flags().Bool("loose", false, looseUsage)
+	viper.BindPFlag("stats.loose", statsCmd.Flags().Lookup("loose"))
+
+	// Add flag for the extra notations to find, which may be repeated
+	statsCmd.Flags().StringSlice("accept", nil, acceptUsage)
+	viper.BindPFlag("stats.accept", statsCmd.Flags().Lookup("accept"))
+
+	// Add flag for the prefixes to match, which may be repeated
+	statsCmd.Flags().StringSlice("match-prefix", nil, matchPrefixUsage)
+	viper.BindPFlag("stats.match-prefix", statsCmd.Flags().Lookup("match-prefix"))
+
+	// Add flag for output file path
+	statsCmd.Flags().StringP("output-file", "o", "", "write output to file")
+	viper.BindPFlag("stats.output-file", statsCmd.Flags().Lookup("output-file"))
+
+	// Set to the value of the --append flag if set
+	statsCmd.Flags().BoolP("append", "a", false, "append when writing to file with --output-file")
+	viper.BindPFlag("stats.append", statsCmd.Flags().Lookup("append"))
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestStatsAction tests the statsAction function
+func TestStatsAction(t *testing.T) {
+	// Create a test CSV database, in memory
+	csvData := `Registry,Assignment,Organization Name,Organization Address
+MA-L,00000C,"Cisco Systems, Inc",170 WEST TASMAN DRIVE SAN JOSE CA US 95134
+MA-L,00005E,"ICANN, IANA Department",INTERNET ASSIGNED NUMBERS AUTHORITY Los Angeles CA US 90094`
+
+	input := "0000.0c01.0203 00:00:0c:01:02:03 00:00:0c:0a:0b:0c\n" +
+		"52:54:00:12:34:56 ff:ff:ff:ff:ff:ff 00:1a:2b:00:00:01"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		unique   bool
+		top      int
+		json     bool
+		csv      bool
+		expected string
+	}{
+		{
+			name: "Text",
+			top:  10,
+			expected: "Total:      6\n" +
+				"Unique:     5\n" +
+				"Unicast:    5\n" +
+				"Multicast:  1\n" +
+				"UAA:        4\n" +
+				"LAA:        2\n" +
+				"\n" +
+				"Vendors:\n" +
+				"      3  Cisco Systems, Inc\n" +
+				"      1  QEMU/KVM\n" +
+				"      1  unknown\n" +
+				"\n" +
+				"Special addresses:\n" +
+				"      1  Broadcast\n" +
+				"\n" +
+				"Registries:\n" +
+				"      3  MA-L\n" +
+				"      3  unknown\n" +
+				"\n" +
+				"Categories:\n" +
+				"      3  networking\n" +
+				"      2  unknown\n" +
+				"      1  virtual\n",
+		},
+		{
+			name:   "UniqueTop",
+			unique: true,
+			top:    1,
+			csv:    true,
+			expected: "section,name,count\n" +
+				"total,,6\n" +
+				"unique,,5\n" +
+				"type,unicast,4\n" +
+				"type,multicast,1\n" +
+				"administration,uaa,3\n" +
+				"administration,laa,2\n" +
+				"vendor,\"Cisco Systems, Inc\",2\n" +
+				"special,Broadcast,1\n" +
+				"registry,unknown,3\n" +
+				"registry,MA-L,2\n" +
+				"category,networking,2\n" +
+				"category,unknown,2\n" +
+				"category,virtual,1\n",
+		},
+		{
+			name: "JSON",
+			top:  2,
+			json: true,
+			expected: `{"total":6,"unique":5,"unicast":5,"multicast":1,"uaa":4,"laa":2,` +
+				`"vendors":[{"name":"Cisco Systems, Inc","count":3},{"name":"QEMU/KVM","count":1}],` +
+				`"special":[{"name":"Broadcast","count":1}],` +
+				`"registries":[{"name":"MA-L","count":3},{"name":"unknown","count":3}],` +
+				`"categories":[{"name":"networking","count":3},{"name":"unknown","count":2},{"name":"virtual","count":1}]}` + "\n",
+		},
+	}
+
+	defer viper.Set("stats.unique", false)
+	defer viper.Set("stats.top", 10)
+	defer viper.Set("stats.json", false)
+	defer viper.Set("stats.csv", false)
+
+	// Run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Set("stats.unique", tc.unique)
+			viper.Set("stats.top", tc.top)
+			viper.Set("stats.json", tc.json)
+			viper.Set("stats.csv", tc.csv)
+
+			// Call the function to test
+			var output bytes.Buffer
+			if err := statsAction(&output, strings.NewReader(csvData), newStringInput(input)); err != nil {
+				t.Fatalf("statsAction() returned error: %v", err)
+			}
+
+			// Check the output
+			if output.String() != tc.expected {
+				t.Errorf("statsAction() output =\n%s\nwant\n%s", output.String(), tc.expected)
+			}
+		})
+	}
+}
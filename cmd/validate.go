@@ -0,0 +1,256 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bitcanon/mactool/cli"
+	"github.com/bitcanon/mactool/debug"
+	"github.com/bitcanon/mactool/mac"
+	"github.com/bitcanon/mactool/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// errInvalidMacAddresses is returned when any of the validated MAC
+// addresses are malformed, so that the command exits with an error code
+var errInvalidMacAddresses = errors.New("invalid MAC addresses found")
+
+// validateCandidates returns the MAC addresses to validate in the line. A
+// line that is a single word, or a well-formed MAC address in any notation,
+// is validated as a whole. Otherwise the words of the line that look like
+// MAC addresses are validated, so that text around them, such as the host
+// and port of an inventory line, is not reported as malformed.
+func validateCandidates(text string) []string {
+	// Nothing to validate on an empty line
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	// Validate the whole line if it is a MAC address or a single word
+	words := strings.Fields(text)
+	if len(words) == 1 || mac.Validate(text) == nil {
+		return []string{text}
+	}
+
+	// Validate the words that look like MAC addresses
+	var candidates []string
+	for _, word := range words {
+		word = strings.Trim(word, "\"'()[]{}<>,;")
+		if isMacCandidate(word) {
+			candidates = append(candidates, word)
+		}
+	}
+	return candidates
+}
+
+// isMacCandidate reports whether the word looks like a MAC address, well-formed
+// or not. The word must be letters, digits and delimiters, close to the length
+// of a MAC address and mostly hex digits. Words without delimiters must be a
+// MAC address in bare or hex notation, and the :: of IPv6 addresses is rejected.
+func isMacCandidate(word string) bool {
+	// Reject the words that can not be told apart from other values
+	if strings.Contains(word, "::") {
+		return false
+	}
+	if !strings.ContainsAny(word, ":-.") {
+		return mac.Validate(word) == nil
+	}
+
+	// Count the letters and digits, and the ones that are not hex digits
+	length, nonHex := 0, 0
+	for _, c := range word {
+		switch {
+		case c == ':' || c == '-' || c == '.':
+		case '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F':
+			length++
+		case 'g' <= c && c <= 'z' || 'G' <= c && c <= 'Z':
+			length++
+			nonHex++
+		default:
+			return false
+		}
+	}
+	return 10 <= length && length <= 20 && nonHex <= 2
+}
+
+// validateAction validates the MAC addresses on each line of the input. By
+// default, the malformed MAC addresses are printed along with the reason.
+// In report mode, every line is printed with its file name, line number and
+// status. An error is returned if any of the MAC addresses are malformed.
+func validateAction(out io.Writer, input cli.Input) error {
+	// Buffer the output since it is written one line at a time
+	w := bufio.NewWriter(out)
+
+	// Print every line in report mode, and nothing in quiet mode
+	report := viper.GetBool("validate.report")
+	quiet := viper.GetBool("validate.quiet")
+
+	// Count the MAC addresses validated and the malformed ones
+	total, invalid := 0, 0
+
+	// Process the input one line at a time
+	err := input.Scan(func(line cli.Line) error {
+		for _, address := range validateCandidates(line.Text) {
+			total++
+
+			// Validate the MAC address
+			status := "valid"
+			if err := mac.Validate(address); err != nil {
+				invalid++
+				status = "invalid: " + err.Error()
+			} else if !report {
+				// Only malformed MAC addresses are printed by default
+				continue
+			}
+
+			// Print the status of the MAC address
+			if quiet {
+				continue
+			}
+			if report {
+				fmt.Fprintf(w, "%s:%d: ", line.Source, line.Number)
+			}
+			fmt.Fprintf(w, "%s: %s\n", address, status)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write any buffered output to the output writer
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// Fail if any of the MAC addresses are malformed
+	if invalid > 0 {
+		return fmt.Errorf("%w: %d of %d", errInvalidMacAddresses, invalid, total)
+	}
+	return nil
+}
+
+// Example help text for the validate command
+const validateExample = `  mactool validate 00:00:5e:00:53:01 00:00-5e:00:53:01
+  mactool validate -i inventory.txt
+  mactool validate --report -r -i inventory/
+  mactool validate -q -i inventory.txt && echo "all valid"
+
+Interactive mode:
+  mactool validate`
+
+// Long help text for the validate command
+const validateLong = `Validate MAC addresses, one per line or argument
+
+Each MAC address is checked for non-hex characters, mixed delimiters,
+wrong length (12 or 16 hex digits) and inconsistent group sizes. The
+malformed MAC addresses are printed along with the reason, or every
+address is printed with --report.
+
+A line with a single word is validated as a MAC address. On lines with
+more text, such as "sw1 Gi0/1 00:1a:2b:3c:4d:5e" in an inventory file,
+only the words that look like MAC addresses are validated: letters,
+digits and delimiters, 10 to 20 digits long and mostly hex digits. The command exits with an error code
+if any of the MAC addresses are malformed.`
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:          "validate [address...]",
+	Short:        "Validate MAC addresses and report malformed ones",
+	Long:         validateLong,
+	Example:      validateExample,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Validate each argument as a separate MAC address,
+		// since MAC addresses may contain spaces
+		if len(args) > 0 {
+			args = []string{strings.Join(args, "\n")}
+		}
+
+		// Open the input from file, pipe, arguments or interactive mode
+		input, err := openInput("validate", args)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		// Determine the output file using Viper
+		outputFile := viper.GetString("validate.output-file")
+		append := viper.GetBool("validate.append")
+
+		// Get the output stream
+		outStream, err := utils.GetOutputStream(outputFile, append)
+		if err != nil {
+			return err
+		}
+		defer outStream.Close()
+
+		// Print the configuration debug if the --debug flag is set
+		if viper.GetBool("debug") {
+			debug.PrintConfigDebug()
+		}
+
+		// Only the exit code tells the result in quiet mode
+		if viper.GetBool("validate.quiet") {
+			cmd.SilenceErrors = true
+		}
+
+		// Validate the MAC addresses in the input
+		return validateAction(outStream, input)
+	},
+}
+
+func init() {
+	// Add the validate command to the root command
+	rootCmd.AddCommand(validateCmd)
+
+	// Set to the value of the --report flag if set
+	validateCmd.Flags().Bool("report", false, "print every line with its file name, line number and status")
+	viper.BindPFlag("validate.report", validateCmd.Flags().Lookup("report"))
+
+	// Set to the value of the --quiet flag if set
+	validateCmd.Flags().BoolP("quiet", "q", false, "print nothing, only exit with an error code if any address is malformed")
+	viper.BindPFlag("validate.quiet", validateCmd.Flags().Lookup("quiet"))
+
+	// Add flag for input file paths, which may be repeated
+	validateCmd.Flags().StringSliceP("input-file", "i", nil, "read input from file, directory or glob pattern (may be repeated)")
+	viper.BindPFlag("validate.input-file", validateCmd.Flags().Lookup("input-file"))
+
+	// Set to the value of the --recursive flag if set
+	validateCmd.Flags().BoolP("recursive", "r", false, "read all files in directories given with --input-file")
+	viper.BindPFlag("validate.recursive", validateCmd.Flags().Lookup("recursive"))
+
+	// Add flag for output file path
+	validateCmd.Flags().StringP("output-file", "o", "", "write output to file")
+	viper.BindPFlag("validate.output-file", validateCmd.Flags().Lookup("output-file"))
+
+	// Set to the value of the --append flag if set
+	validateCmd.Flags().BoolP("append", "a", false, "append when writing to file with --output-file")
+	viper.BindPFlag("validate.append", validateCmd.Flags().Lookup("append"))
+}
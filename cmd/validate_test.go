@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestValidateAction tests the validateAction function
+func TestValidateAction(t *testing.T) {
+	input := "00:00:5e:00:53:01\n\n00:11-22.33:44:55\n  0000.5e00.5302  \n00:00:5e:00:53\n"
+
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		input    string
+		report   bool
+		quiet    bool
+		expected string
+		invalid  bool
+	}{
+		{
+			name:  "InvalidOnly",
+			input: input,
+			expected: "00:11-22.33:44:55: invalid: mixed delimiters: \":\" \"-\" \".\"\n" +
+				"00:00:5e:00:53: invalid: wrong length; must be 12 (EUI-48) or 16 (EUI-64) hex digits, got 10\n",
+			invalid: true,
+		},
+		{
+			name:   "Report",
+			input:  input,
+			report: true,
+			expected: "(arguments):1: 00:00:5e:00:53:01: valid\n" +
+				"(arguments):3: 00:11-22.33:44:55: invalid: mixed delimiters: \":\" \"-\" \".\"\n" +
+				"(arguments):4: 0000.5e00.5302: valid\n" +
+				"(arguments):5: 00:00:5e:00:53: invalid: wrong length; must be 12 (EUI-48) or 16 (EUI-64) hex digits, got 10\n",
+			invalid: true,
+		},
+		{
+			name:     "Quiet",
+			input:    input,
+			quiet:    true,
+			expected: "",
+			invalid:  true,
+		},
+		{
+			name: "MixedText",
+			input: "sw1 Gi0/1 00:1a:2b:3c:4d:5e\n" +
+				"sw1 Gi0/2 00:1a:2b:3c:4d:zz vlan 10 at 2023-10-18 12:00:00\n" +
+				"sw2 fe80::21a:2bff:fe3c:4d5e 192.0.2.1 \"001a.2b3c-4d5e\",\n" +
+				"00 1a 2b 3c 4d 5f\n",
+			report: true,
+			expected: "(arguments):1: 00:1a:2b:3c:4d:5e: valid\n" +
+				"(arguments):2: 00:1a:2b:3c:4d:zz: invalid: non-hex characters: 'z'\n" +
+				"(arguments):3: 001a.2b3c-4d5e: invalid: mixed delimiters: \".\" \"-\"\n" +
+				"(arguments):4: 00 1a 2b 3c 4d 5f: valid\n",
+			invalid: true,
+		},
+		{
+			name:     "AllValid",
+			input:    "00:00:5e:00:53:01\n0000.5e00.5302\n",
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// Set the flags
+			viper.Set("validate.report", test.report)
+			viper.Set("validate.quiet", test.quiet)
+
+			// Call the function to test
+			var output bytes.Buffer
+			err := validateAction(&output, newStringInput(test.input))
+
+			// An error is returned if any MAC address is invalid
+			if test.invalid && !errors.Is(err, errInvalidMacAddresses) {
+				t.Errorf("expected errInvalidMacAddresses, but got %v", err)
+			}
+			if !test.invalid && err != nil {
+				t.Errorf("error returned from validateAction(): %v", err)
+			}
+
+			// Compare the results to the expected values
+			if output.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, output.String())
+			}
+		})
+	}
+}
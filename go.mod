@@ -3,6 +3,7 @@ module github.com/bitcanon/mactool
 go 1.21.0
 
 require (
+	github.com/klauspost/compress v1.17.11
 	github.com/spf13/cobra v1.7.0
 	github.com/spf13/viper v1.16.0
 )
@@ -0,0 +1,150 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import (
+	"errors"
+	"math"
+)
+
+// Errors returned by the MAC address arithmetic
+var ErrAddressOverflow = errors.New("MAC address out of range")
+var ErrOuiOverflow = errors.New("MAC address outside of the OUI")
+var ErrLengthMismatch = errors.New("MAC addresses of different lengths")
+
+// Add returns the MAC address offset by a number of addresses, which may
+// be negative to subtract. The notation of the MAC address is kept, so
+// 00:00:5e:00:53:ff plus 1 is 00:00:5e:00:54:00. ErrAddressOverflow is
+// returned if the result is below zero or above the largest MAC address.
+func Add(macAddress string, offset int64) (string, error) {
+	octets, err := octetsOf(macAddress)
+	if err != nil {
+		return "", err
+	}
+	value := octetsToUint(octets)
+
+	// Check that the result is within the range of MAC addresses
+	max := uint64(math.MaxUint64) >> (64 - 8*len(octets))
+	if offset >= 0 {
+		if uint64(offset) > max-value {
+			return "", ErrAddressOverflow
+		}
+		value += uint64(offset)
+	} else {
+		// Negate offset+1 to not overflow the smallest int64
+		if uint64(-(offset+1))+1 > value {
+			return "", ErrAddressOverflow
+		}
+		value -= uint64(-(offset + 1)) + 1
+	}
+
+	return withOctets(macAddress, uintToOctets(value, len(octets))), nil
+}
+
+// AddWithinOui works like Add, but also returns ErrOuiOverflow if the
+// result is outside of the OUI (the first three octets) of the MAC address
+func AddWithinOui(macAddress string, offset int64) (string, error) {
+	result, err := Add(macAddress, offset)
+	if err != nil {
+		return "", err
+	}
+	before, _ := ExtractOuiFromMac(macAddress)
+	after, _ := ExtractOuiFromMac(result)
+	if before != after {
+		return "", ErrOuiOverflow
+	}
+	return result, nil
+}
+
+// Difference returns the number of addresses from the MAC address a to the
+// MAC address b, which is negative if b is below a. Both MAC addresses must
+// have the same length. ErrAddressOverflow is returned if the difference
+// between two EUI-64 addresses does not fit in an int64.
+func Difference(a string, b string) (int64, error) {
+	x, y, err := valuesOf(a, b)
+	if err != nil {
+		return 0, err
+	}
+	if y >= x {
+		if y-x > math.MaxInt64 {
+			return 0, ErrAddressOverflow
+		}
+		return int64(y - x), nil
+	}
+	if x-y > math.MaxInt64 {
+		return 0, ErrAddressOverflow
+	}
+	return -int64(x - y), nil
+}
+
+// Compare compares the MAC addresses a and b by their numeric value,
+// regardless of notation and case. It returns -1 if a is below b, 0 if
+// they are the same address, and 1 if a is above b.
+func Compare(a string, b string) (int, error) {
+	x, y, err := valuesOf(a, b)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case x < y:
+		return -1, nil
+	case x > y:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// valuesOf returns the numeric values of two MAC addresses of the same length
+func valuesOf(a string, b string) (uint64, uint64, error) {
+	x, err := octetsOf(a)
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := octetsOf(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(x) != len(y) {
+		return 0, 0, ErrLengthMismatch
+	}
+	return octetsToUint(x), octetsToUint(y), nil
+}
+
+// octetsToUint returns the numeric value of the octets, most significant first
+func octetsToUint(octets []byte) uint64 {
+	var value uint64
+	for _, octet := range octets {
+		value = value<<8 | uint64(octet)
+	}
+	return value
+}
+
+// uintToOctets returns the numeric value as the number of octets, most
+// significant first
+func uintToOctets(value uint64, length int) []byte {
+	octets := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		octets[i] = byte(value)
+		value >>= 8
+	}
+	return octets
+}
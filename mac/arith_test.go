@@ -0,0 +1,89 @@
+package mac
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestAdd tests the Add and AddWithinOui functions
+func TestAdd(t *testing.T) {
+	testCases := []struct {
+		name       string
+		macAddress string
+		offset     int64
+		withinOui  bool
+		expected   string
+		err        error
+	}{
+		{"Next", "00:00:5e:00:53:01", 1, false, "00:00:5e:00:53:02", nil},
+		{"Carry", "00:00:5e:00:53:ff", 1, false, "00:00:5e:00:54:00", nil},
+		{"Subtract", "0000.5E00.5400", -1, false, "0000.5E00.53FF", nil},
+		{"KeepsNotation", "0x00005e005301", 16, false, "0x00005e005311", nil},
+		{"Zero", "00-00-5E-00-53-01", 0, false, "00-00-5E-00-53-01", nil},
+		{"Largest", "ff:ff:ff:ff:ff:fe", 1, false, "ff:ff:ff:ff:ff:ff", nil},
+		{"AboveLargest", "ff:ff:ff:ff:ff:ff", 1, false, "", ErrAddressOverflow},
+		{"BelowZero", "00:00:00:00:00:00", -1, false, "", ErrAddressOverflow},
+		{"SmallestOffset", "ff:ff:ff:ff:ff:ff", math.MinInt64, false, "", ErrAddressOverflow},
+		{"EUI64", "02:00:5e:10:00:00:00:ff", 1, false, "02:00:5e:10:00:00:01:00", nil},
+		{"WithinOui", "00:00:5e:ff:ff:fe", 1, true, "00:00:5e:ff:ff:ff", nil},
+		{"OutsideOui", "00:00:5e:ff:ff:ff", 1, true, "", ErrOuiOverflow},
+		{"OutsideOuiBelow", "00:00:5e:00:00:00", -1, true, "", ErrOuiOverflow},
+		{"Invalid", "00:00:5e:00:53", 1, false, "", ErrInvalidMacAddress},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			add := Add
+			if test.withinOui {
+				add = AddWithinOui
+			}
+			result, err := add(test.macAddress, test.offset)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+// TestDifferenceAndCompare tests the Difference and Compare functions
+func TestDifferenceAndCompare(t *testing.T) {
+	testCases := []struct {
+		name       string
+		a          string
+		b          string
+		difference int64
+		compare    int
+		err        error
+	}{
+		{"Same", "00:00:5e:00:53:01", "0000.5E00.5301", 0, 0, nil},
+		{"Above", "00:00:5e:00:53:01", "00-00-5e-00-53-10", 15, -1, nil},
+		{"Below", "00:00:5e:00:54:00", "00:00:5e:00:53:ff", -1, 1, nil},
+		{"EUI64", "00:00:00:00:00:00:00:00", "ff:ff:ff:ff:ff:ff:ff:ff", 0, -1, ErrAddressOverflow},
+		{"LengthMismatch", "00:00:5e:00:53:01", "02:00:5e:10:00:00:00:01", 0, 0, ErrLengthMismatch},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			difference, err := Difference(test.a, test.b)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if difference != test.difference {
+				t.Errorf("expected difference %d, but got %d", test.difference, difference)
+			}
+
+			// Comparing only fails for invalid MAC addresses
+			compare, err := Compare(test.a, test.b)
+			if err != nil && !errors.Is(err, ErrLengthMismatch) {
+				t.Fatalf("error returned from Compare(): %v", err)
+			}
+			if compare != test.compare {
+				t.Errorf("expected compare %d, but got %d", test.compare, compare)
+			}
+		})
+	}
+}
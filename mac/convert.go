@@ -0,0 +1,225 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BitReverse converts a MAC address between canonical and non-canonical
+// (bit-reversed) form by reversing the order of the bits in each octet, as
+// used by Token Ring and FDDI. The notation of the MAC address is kept, so
+// 00:00:5e:00:53:01 becomes 00:00:7a:00:ca:80. Reversing twice gives back
+// the original MAC address.
+func BitReverse(macAddress string) (string, error) {
+	octets, err := octetsOf(macAddress)
+	if err != nil {
+		return "", err
+	}
+
+	// Reverse the bits of each octet
+	for i, octet := range octets {
+		octets[i] = reverseBits(octet)
+	}
+	return withOctets(macAddress, octets), nil
+}
+
+// withOctets returns the MAC address with its hex digits replaced by the
+// octets, keeping its notation. The octets are written in upper case if
+// the MAC address has upper case hex digits, otherwise in lower case.
+func withOctets(macAddress string, octets []byte) string {
+	// Keep the 0x prefix of MAC addresses in hex notation
+	body := trimHexPrefix(macAddress)
+	prefix := macAddress[:len(macAddress)-len(body)]
+
+	// Get the hex digits of the octets in the case of the MAC address
+	digits := make([]byte, 0, len(octets)*2)
+	for _, octet := range octets {
+		digits = append(digits, hexDigits[octet>>4], hexDigits[octet&0x0f])
+	}
+	if strings.ContainsAny(body, "ABCDEF") {
+		digits = []byte(strings.ToUpper(string(digits)))
+	}
+
+	// Put the hex digits in place of the original ones,
+	// keeping the delimiters of the MAC address
+	result := []byte(body)
+	next := 0
+	for i := range result {
+		if isHex(result[i]) && next < len(digits) {
+			result[i] = digits[next]
+			next++
+		}
+	}
+	return prefix + string(result)
+}
+
+// hexDigits are the hex digits in lower case, indexed by their value
+const hexDigits = "0123456789abcdef"
+
+// hexValue returns the value of the hex digit
+func hexValue(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// octetsOf returns the octets of the MAC address, which must have 12 (EUI-48)
+// or 16 (EUI-64) hex digits in any of the notations with hex digits
+func octetsOf(macAddress string) ([]byte, error) {
+	digits := cleanMacAddress(trimHexPrefix(macAddress))
+	if len(digits) != 12 && len(digits) != 16 {
+		return nil, ErrInvalidMacAddress
+	}
+	octets := make([]byte, len(digits)/2)
+	for i := range octets {
+		octets[i] = hexValue(digits[i*2])<<4 | hexValue(digits[i*2+1])
+	}
+	return octets, nil
+}
+
+// fromOctets returns the MAC address of the octets as colon-separated
+// hex digits in lower case, as in 00:00:5e:00:53:01
+func fromOctets(octets []byte) string {
+	groups := make([]string, len(octets))
+	for i, octet := range octets {
+		groups[i] = string([]byte{hexDigits[octet>>4], hexDigits[octet&0x0f]})
+	}
+	return strings.Join(groups, ":")
+}
+
+// ParseDecimal parses a MAC address in dotted decimal, as in 0.0.94.0.83.1
+// where each of the six (EUI-48) or eight (EUI-64) octets is a decimal
+// number, and returns it as colon-separated hex digits (00:00:5e:00:53:01)
+func ParseDecimal(s string) (string, error) {
+	fields := strings.Split(s, ".")
+	if len(fields) != 6 && len(fields) != 8 {
+		return "", fmt.Errorf("%w: %q must be 6 or 8 decimal octets separated by periods", ErrInvalidMacAddress, s)
+	}
+	octets := make([]byte, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 8)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q is not a decimal octet (0-255)", ErrInvalidMacAddress, field)
+		}
+		octets[i] = byte(value)
+	}
+	return fromOctets(octets), nil
+}
+
+// ParseInteger parses an EUI-48 MAC address written as an unsigned integer,
+// as in 1577079553, and returns it as colon-separated hex digits
+// (00:00:5e:00:53:01). Integers of more than 48 bits are rejected, so that
+// an integer off by some orders of magnitude is not taken for an EUI-64
+// address; those are parsed by ParseInteger64.
+func ParseInteger(s string) (string, error) {
+	value, err := parseUint64(s)
+	if err != nil {
+		return "", err
+	}
+	if value >= 1<<48 {
+		return "", fmt.Errorf("%w: %q is more than 48 bits", ErrInvalidMacAddress, s)
+	}
+	return fromOctets(uintToOctets(value, 6)), nil
+}
+
+// ParseInteger64 parses an EUI-64 MAC address written as an unsigned
+// integer, as in 1577079553, and returns it as colon-separated hex digits
+// (00:00:00:00:5e:00:53:01)
+func ParseInteger64(s string) (string, error) {
+	value, err := parseUint64(s)
+	if err != nil {
+		return "", err
+	}
+	return fromOctets(uintToOctets(value, 8)), nil
+}
+
+// parseUint64 parses the decimal digits of an unsigned 64-bit integer
+func parseUint64(s string) (uint64, error) {
+	value, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not an unsigned 64-bit integer", ErrInvalidMacAddress, s)
+	}
+	return value, nil
+}
+
+// ParseBinary parses a MAC address written as 48 (EUI-48) or 64 (EUI-64)
+// binary digits, optionally with a colon, hyphen, period or space between
+// the octets, and returns it as colon-separated hex digits
+func ParseBinary(s string) (string, error) {
+	digits := strings.NewReplacer(":", "", "-", "", ".", "", " ", "").Replace(s)
+	if len(digits) != 48 && len(digits) != 64 {
+		return "", fmt.Errorf("%w: %q must be 48 or 64 binary digits", ErrInvalidMacAddress, s)
+	}
+	octets := make([]byte, len(digits)/8)
+	for i := range octets {
+		value, err := strconv.ParseUint(digits[i*8:i*8+8], 2, 8)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q is not binary", ErrInvalidMacAddress, s)
+		}
+		octets[i] = byte(value)
+	}
+	return fromOctets(octets), nil
+}
+
+// FormatDecimal returns the MAC address in dotted decimal, as in 0.0.94.0.83.1
+func FormatDecimal(macAddress string) (string, error) {
+	octets, err := octetsOf(macAddress)
+	if err != nil {
+		return "", err
+	}
+	fields := make([]string, len(octets))
+	for i, octet := range octets {
+		fields[i] = strconv.Itoa(int(octet))
+	}
+	return strings.Join(fields, "."), nil
+}
+
+// FormatInteger returns the MAC address as an unsigned integer, as in 1577079553
+func FormatInteger(macAddress string) (string, error) {
+	octets, err := octetsOf(macAddress)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(octetsToUint(octets), 10), nil
+}
+
+// FormatBinary returns the MAC address as binary digits, eight per octet
+// and without delimiters, most significant bit first
+func FormatBinary(macAddress string) (string, error) {
+	octets, err := octetsOf(macAddress)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, octet := range octets {
+		fmt.Fprintf(&b, "%08b", octet)
+	}
+	return b.String(), nil
+}
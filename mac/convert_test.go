@@ -0,0 +1,119 @@
+package mac
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestBitReverse tests the BitReverse function
+func TestBitReverse(t *testing.T) {
+	testCases := []struct {
+		name       string
+		macAddress string
+		expected   string
+		err        error
+	}{
+		{"Colon", "00:00:5e:00:53:01", "00:00:7a:00:ca:80", nil},
+		{"HyphenUpper", "00-00-5E-00-53-01", "00-00-7A-00-CA-80", nil},
+		{"Dot", "0000.5e00.5301", "0000.7a00.ca80", nil},
+		{"Bare", "00005e005301", "00007a00ca80", nil},
+		{"Hex", "0x00005E005301", "0x00007A00CA80", nil},
+		{"Space", "00 00 5e 00 53 01", "00 00 7a 00 ca 80", nil},
+		{"EUI64", "02:00:5e:10:00:00:00:01", "40:00:7a:08:00:00:00:80", nil},
+		{"AllBits", "ff:ff:ff:ff:ff:ff", "ff:ff:ff:ff:ff:ff", nil},
+		{"TokenRing", "10:00:5a:12:34:56", "08:00:5a:48:2c:6a", nil},
+		{"TooShort", "00:00:5e:00:53", "", ErrInvalidMacAddress},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := BitReverse(test.macAddress)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, result)
+			}
+
+			// Reversing twice gives back the original MAC address
+			if err == nil {
+				if back, _ := BitReverse(result); back != test.macAddress {
+					t.Errorf("expected %q when reversed twice, but got %q", test.macAddress, back)
+				}
+			}
+		})
+	}
+}
+
+// TestParseRepresentations tests the ParseDecimal, ParseInteger,
+// ParseInteger64 and ParseBinary functions
+func TestParseRepresentations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parse    func(string) (string, error)
+		input    string
+		expected string
+		err      error
+	}{
+		{"Decimal", ParseDecimal, "0.26.43.60.77.94", "00:1a:2b:3c:4d:5e", nil},
+		{"DecimalEUI64", ParseDecimal, "2.0.94.16.0.0.0.1", "02:00:5e:10:00:00:00:01", nil},
+		{"DecimalOutOfRange", ParseDecimal, "0.26.43.60.77.256", "", ErrInvalidMacAddress},
+		{"DecimalTooShort", ParseDecimal, "192.0.2.1", "", ErrInvalidMacAddress},
+		{"Integer", ParseInteger, "112394521950", "00:1a:2b:3c:4d:5e", nil},
+		{"IntegerZero", ParseInteger, "0", "00:00:00:00:00:00", nil},
+		{"IntegerMax48", ParseInteger, "281474976710655", "ff:ff:ff:ff:ff:ff", nil},
+		{"IntegerMoreThan48Bits", ParseInteger, "281474976710656", "", ErrInvalidMacAddress},
+		{"IntegerNegative", ParseInteger, "-1", "", ErrInvalidMacAddress},
+		{"Integer64", ParseInteger64, "1577079553", "00:00:00:00:5e:00:53:01", nil},
+		{"Integer64Max", ParseInteger64, "18446744073709551615", "ff:ff:ff:ff:ff:ff:ff:ff", nil},
+		{"Integer64MoreThan64Bits", ParseInteger64, "18446744073709551616", "", ErrInvalidMacAddress},
+		{"Integer64NotInteger", ParseInteger64, "0x1a", "", ErrInvalidMacAddress},
+		{"Binary", ParseBinary, "000000000001101000101011001111000100110101011110", "00:1a:2b:3c:4d:5e", nil},
+		{"BinaryDelimited", ParseBinary, "00000000:00011010:00101011:00111100:01001101:01011110", "00:1a:2b:3c:4d:5e", nil},
+		{"BinaryNotBinary", ParseBinary, "000000000001101000101011001111000100110101011112", "", ErrInvalidMacAddress},
+		{"BinaryTooShort", ParseBinary, "0101", "", ErrInvalidMacAddress},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.parse(test.input)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+// TestFormatRepresentations tests the FormatDecimal, FormatInteger
+// and FormatBinary functions
+func TestFormatRepresentations(t *testing.T) {
+	testCases := []struct {
+		name       string
+		format     func(string) (string, error)
+		macAddress string
+		expected   string
+		err        error
+	}{
+		{"Decimal", FormatDecimal, "00:1A:2B:3C:4D:5E", "0.26.43.60.77.94", nil},
+		{"DecimalCisco", FormatDecimal, "001a.2b3c.4d5e", "0.26.43.60.77.94", nil},
+		{"Integer", FormatInteger, "00-1a-2b-3c-4d-5e", "112394521950", nil},
+		{"IntegerEUI64", FormatInteger, "ff:ff:ff:ff:ff:ff:ff:ff", "18446744073709551615", nil},
+		{"Binary", FormatBinary, "0x001a2b3c4d5e", "000000000001101000101011001111000100110101011110", nil},
+		{"Invalid", FormatInteger, "00:1a:2b", "", ErrInvalidMacAddress},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.format(test.macAddress)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, result)
+			}
+		})
+	}
+}
@@ -23,7 +23,6 @@ package mac
 
 import (
 	"errors"
-	"fmt"
 	"regexp"
 	"strings"
 )
@@ -72,45 +71,49 @@ type MacFormat struct {
 	GroupSize GroupSizeOption
 }
 
-// cleanMacAddress removes all non-alphanumeric characters from the MAC address.
-func cleanMacAddress(macAddress string) string {
-	// Use a regular expression to match non-alphanumeric characters
-	reg := regexp.MustCompile("[^A-Fa-f0-9]")
-
-	// Remove all non-alphanumeric characters from the MAC address
-	return reg.ReplaceAllString(macAddress, "")
+// Regular expressions used to strip delimiters from MAC addresses. They are
+// compiled once since they are used for every address that is processed.
+var nonHexRegexp = regexp.MustCompile("[^A-Fa-f0-9]")
+var nonAlphanumericRegexp = regexp.MustCompile(`[^0-9a-zA-Z]`)
+
+// macSystem describes a MAC address notation by the number of
+// groups and the number of characters in each group.
+type macSystem struct {
+	groupCount int
+	groupSize  int
+	notation   Notation
 }
 
-// extractMacAddresses extracts MAC addresses from the input string.
-// The groupCount parameter is the number of groups of MAC
-// address characters. The groupSize parameter is the number of
-// characters in each group. Each group is separated by a colon,
-// dash or period.
-func extractMacAddresses(input string, groupCount int, groupSize int) ([]string, string, error) {
-	// Regular expression pattern to match MAC addresses
-	pattern := fmt.Sprintf(`((?:[\da-fA-F]{%d}[:\.-]){%d}[\da-fA-F]{%d})`,
-		groupSize, groupCount-1, groupSize)
-
-	// Compile the regular expression pattern
-	re, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil, input, err
+// newMacSystem creates a macSystem with the number of groups, the
+// number of characters in each group and the name of the notation.
+func newMacSystem(groupCount int, groupSize int, notation Notation) macSystem {
+	return macSystem{
+		groupCount: groupCount,
+		groupSize:  groupSize,
+		notation:   notation,
 	}
+}
 
-	// Save the MAC addresses found in the input string
-	// and remove them from the input string
-	addresses := re.FindAllString(input, -1)
-	modifiedInput := re.ReplaceAllString(input, "")
+// macSystems defines the MAC address systems to search for, in order of
+// most specific to least specific. This is done to avoid false positives.
+var macSystems = []macSystem{
+	newMacSystem(8, 2, OctetNotation), // EUI-64 : 02:00:5e:10:00:00:00:01
+	newMacSystem(4, 4, CiscoNotation), // EUI-64 : 0200.5e10.0000.0001
+	newMacSystem(6, 2, OctetNotation), // EUI-48 : 00:00:5e:00:53:01
+	newMacSystem(3, 4, CiscoNotation), // EUI-48 : 0000.5e00.5301
+	newMacSystem(2, 6, HPNotation),    // EUI-48 : 00005e-005301
+}
 
-	// Return the list of MAC addresses found in the input string
-	// and the input string with the MAC addresses removed
-	return addresses, modifiedInput, nil
+// cleanMacAddress removes all non-alphanumeric characters from the MAC address.
+func cleanMacAddress(macAddress string) string {
+	// Remove all non-alphanumeric characters from the MAC address
+	return nonHexRegexp.ReplaceAllString(macAddress, "")
 }
 
 // findMacDelimiter finds the delimiter used in the MAC address.
 func findMacDelimiter(macAddress string) string {
-	// Check for a colon, dash or period delimiter
-	delimiters := []string{":", "-", "."}
+	// Check for a colon, dash, period or space delimiter
+	delimiters := []string{":", "-", ".", " "}
 
 	// Check for each delimiter
 	for _, delimiter := range delimiters {
@@ -156,60 +159,41 @@ func formatWithDelimiters(macAddress, delimiter string, groupSize int) (string,
 	return strings.Join(groups, delimiter), nil
 }
 
-// FindAllMacAddresses returns a list of MAC addresses found in the input string.
+// FindAllMacAddresses returns a list of MAC addresses found in the input
+// string, in the order they appear in the input. Use FindAllMatches to also
+// get the position and notation of each MAC address.
 func FindAllMacAddresses(s string) ([]string, error) {
 	// List of MAC addresses found in the input string
 	var addresses []string
 
-	// Define the MAC address systems to search for
-	macSystem := []struct {
-		groupCount int
-		groupSize  int
-	}{
-		{8, 2}, // EUI-64 : 02:00:5e:10:00:00:00:01
-		{4, 4}, // EUI-64 : 0200.5e10.0000.0001
-		{6, 2}, // EUI-48 : 00:00:5e:00:53:01
-		{3, 4}, // EUI-48 : 0000.5e00.5301
-		{2, 6}, // EUI-48 : 00005e-005301
-	}
-
-	// Extract MAC addresses from the input string
-	input := s
-
-	// Loop through the MAC address systems in order of most specific to least
-	// specific. This is done to avoid false positives.
-	for _, ms := range macSystem {
-		// Extract MAC addresses from the output string. The output string is
-		// updated with each iteration to remove the MAC addresses that were
-		// found in the previous iteration.
-		results, processedOutput, err := extractMacAddresses(input, ms.groupCount, ms.groupSize)
-		if err != nil {
-			return nil, err
-		}
-
-		// Append the results to the list of MAC addresses
-		addresses = append(addresses, results...)
-
-		// Update the output string to the processed output
-		input = processedOutput
+	// Keep only the text of each MAC address found
+	for _, m := range FindAllMatches(s) {
+		addresses = append(addresses, m.Text)
 	}
 
 	// Return the list of MAC addresses found in the input string
 	return addresses, nil
 }
 
+// trimHexPrefix removes the 0x prefix from a MAC address in hex notation
+func trimHexPrefix(macAddress string) string {
+	if len(macAddress) > 2 && macAddress[0] == '0' && (macAddress[1] == 'x' || macAddress[1] == 'X') {
+		return macAddress[2:]
+	}
+	return macAddress
+}
+
 // ExtractOuiFromMac extracts the OUI assignment from a MAC address.
 func ExtractOuiFromMac(macAddress string) (string, error) {
+	// Remove the 0x prefix of MAC addresses in hex notation
+	macAddress = trimHexPrefix(macAddress)
+
 	// Make sure the string is uppercase since the
 	// assignment in the OUI database is uppercase
 	macAddress = strings.ToUpper(macAddress)
 
-	// Define a regular expression to remove all non-alphanumeric
-	// characters from the MAC address
-	reg := regexp.MustCompile("[^a-fA-F0-9]+")
-
 	// Remove all non-alphanumeric characters from the MAC address
-	macAddress = reg.ReplaceAllString(macAddress, "")
+	macAddress = nonHexRegexp.ReplaceAllString(macAddress, "")
 
 	// Make sure the MAC address is at least 12 characters long
 	// and return the first 3 bytes (6 hexadecimal characters) as a string
@@ -226,6 +210,9 @@ func ExtractOuiFromMac(macAddress string) (string, error) {
 // The delimiter is the character used to separate each group.
 // Example: 00:00:5E:00:53:01 (Case: Upper, Delimiter: Colon, GroupSize: 2)
 func FormatMacAddress(macAddress string, newFormat MacFormat) (string, error) {
+	// Remove the 0x prefix of MAC addresses in hex notation
+	macAddress = trimHexPrefix(macAddress)
+
 	// Validate the case option
 	switch newFormat.Case {
 	case Upper:
@@ -268,7 +255,13 @@ func FormatMacAddress(macAddress string, newFormat MacFormat) (string, error) {
 	case GroupSizeSix:
 		groupSize = 6
 	case OriginalGroupSize:
-		groupSize, _ = GetGroupSize(macAddress)
+		// MAC addresses without delimiters have no original group
+		// size, so they are split into octets if a delimiter is used
+		var err error
+		groupSize, err = GetGroupSize(macAddress)
+		if err != nil && findMacDelimiter(macAddress) == "" {
+			groupSize = 2
+		}
 	}
 
 	// Format the MAC address
@@ -287,7 +280,7 @@ func FormatMacAddress(macAddress string, newFormat MacFormat) (string, error) {
 // character except alphanumeric characters.
 func GetGroupSize(macAddress string) (int, error) {
 	// Remove all non-alphanumeric characters from the MAC address
-	strippedMAC := regexp.MustCompile(`[^0-9a-zA-Z]`).ReplaceAllString(macAddress, "")
+	strippedMAC := nonAlphanumericRegexp.ReplaceAllString(macAddress, "")
 	strippedLen := len(strippedMAC)
 
 	// Calculate the number of delimiters removed from the MAC address
@@ -309,3 +302,24 @@ func GetGroupSize(macAddress string) (int, error) {
 	// Return the group size and no error
 	return groupSize, nil
 }
+
+// IsMulticast reports whether the MAC address is a group address, which
+// is when the least significant bit of the first octet is set
+func IsMulticast(macAddress string) (bool, error) {
+	octets, err := octetsOf(macAddress)
+	if err != nil {
+		return false, err
+	}
+	return octets[0]&0x01 != 0, nil
+}
+
+// IsLocallyAdministered reports whether the MAC address is locally
+// administered (LAA) rather than universally administered (UAA), which
+// is when the second least significant bit of the first octet is set
+func IsLocallyAdministered(macAddress string) (bool, error) {
+	octets, err := octetsOf(macAddress)
+	if err != nil {
+		return false, err
+	}
+	return octets[0]&0x02 != 0, nil
+}
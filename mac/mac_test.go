@@ -63,6 +63,18 @@ func TestFindAllMacAddresses(t *testing.T) {
 			input:    "00005E-005301",
 			expected: []string{"00005E-005301"},
 		},
+		{
+			input:    "Dot 0000.5E00.5301 before colon 00:00:5E:00:53:02",
+			expected: []string{"0000.5E00.5301", "00:00:5E:00:53:02"},
+		},
+		{
+			input:    "00005e-005303 0000.5e00.5302 00:00:5e:00:53:01 0200.5e10.0000.0001 02:00:5e:10:00:00:00:02",
+			expected: []string{"00005e-005303", "0000.5e00.5302", "00:00:5e:00:53:01", "0200.5e10.0000.0001", "02:00:5e:10:00:00:00:02"},
+		},
+		{
+			input:    "EUI-48 02:00:5e:10:00:00 and EUI-64 02:00:5e:10:00:00:00:01",
+			expected: []string{"02:00:5e:10:00:00", "02:00:5e:10:00:00:00:01"},
+		},
 	}
 
 	// Loop through the test cases
@@ -0,0 +1,272 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+// Match is a MAC address found in the input, along with where it was found.
+// Offset is the byte offset of the address in the input. Line and Column are
+// the line number and the byte column on that line, both counted from 1.
+type Match struct {
+	Text     string
+	Offset   int
+	Line     int
+	Column   int
+	Notation Notation
+}
+
+// End returns the byte offset just after the MAC address in the input
+func (m Match) End() int {
+	return m.Offset + len(m.Text)
+}
+
+// Address returns the MAC address in a notation the other functions of the
+// package read. This is the text as found in the input, except for dotted
+// decimal, which is converted to colon-separated hex digits.
+func (m Match) Address() string {
+	if m.Notation == DecimalNotation {
+		if address, err := ParseDecimal(m.Text); err == nil {
+			return address
+		}
+	}
+	return m.Text
+}
+
+// Scanner finds MAC addresses in text. The zero value is a strict scanner,
+// which only accepts MAC addresses that stand alone as tokens in the text.
+type Scanner struct {
+	// Loose accepts MAC-like fragments inside longer tokens, such as
+	// IPv6 addresses, UUIDs and timestamps, like previous versions did.
+	// Since this finds more numbers by mistake, addresses in bare and
+	// space notation that are all decimal digits are then rejected.
+	Loose bool
+
+	// Accept lists the notations to recognize in addition to the delimited
+	// notations, which are always recognized. Addresses in these notations
+	// must stand alone as tokens, even if Loose is set.
+	Accept []Notation
+}
+
+// FindAllMatches returns the MAC addresses found in the input string by a
+// strict scanner, in the order they appear in the input.
+func FindAllMatches(s string) []Match {
+	return Scanner{}.FindAll(s)
+}
+
+// FindAll returns the MAC addresses found in the input string, in the
+// order they appear in the input. The input is scanned once, and at each
+// position the notations are tried from most specific to least specific.
+// This is done to avoid false positives.
+func (sc Scanner) FindAll(s string) []Match {
+	// List of MAC addresses found in the input string
+	var matches []Match
+
+	// Line number and start of the current line, for the line and column
+	line, lineStart := 1, 0
+
+	for i := 0; i < len(s); {
+		// Skip characters that can not start a MAC address
+		if !isHex(s[i]) {
+			if s[i] == '\n' {
+				line++
+				lineStart = i + 1
+			}
+			i++
+			continue
+		}
+
+		// Find the end of the run of hex digits starting here
+		runEnd := i
+		for runEnd < len(s) && isHex(s[runEnd]) {
+			runEnd++
+		}
+
+		// Try the notations that are only recognized when accepted
+		if m, ok := sc.matchAccepted(s, i, runEnd); ok {
+			m.Line = line
+			m.Column = m.Offset - lineStart + 1
+			matches = append(matches, m)
+			i = m.End()
+			continue
+		}
+
+		// Since the first group of a MAC address is followed by a delimiter,
+		// a MAC address can only start where the rest of the run is exactly
+		// one group long. Try the positions from left to right.
+		m, ok := Match{}, false
+		for _, groupSize := range []int{6, 4, 2} {
+			start := runEnd - groupSize
+			if start < i {
+				continue
+			}
+			if m, ok = matchAt(s, start, groupSize, !sc.Loose); ok {
+				break
+			}
+		}
+
+		// Skip the run if no MAC address starts in it
+		if !ok {
+			i = runEnd
+			continue
+		}
+
+		// Skip the run if the MAC address is part of a longer token. Another
+		// MAC address may still start after the run, as in MAC:00:00:5e:...
+		// where the first match starts in the word MAC.
+		if !sc.Loose && !isStandalone(s, m.Offset, m.End()) {
+			i = runEnd
+			continue
+		}
+		i = m.End()
+
+		// Save the MAC address and where it was found
+		m.Line = line
+		m.Column = m.Offset - lineStart + 1
+		matches = append(matches, m)
+	}
+
+	// Return the MAC addresses found in the input string
+	return matches
+}
+
+// isStandalone reports whether the MAC address between the start and end
+// offsets of the input stands alone as a token. It is not, if it is directly
+// next to a letter or digit, or if it continues with a delimiter and another
+// group of hex digits on either side, as in IPv6 addresses (2001:db8::1),
+// UUIDs, longer hex strings (00:00:5e:00:53:01:ff) and timestamps with
+// fractional seconds (12:34:56:78:90:12.345). A delimiter followed by a word
+// that is not only hex digits is allowed, as in "MAC:00:00:5e:00:53:01".
+func isStandalone(s string, start int, end int) bool {
+	// Check the text before the MAC address
+	if start > 0 {
+		c := s[start-1]
+		if isAlphanumeric(c) {
+			return false
+		}
+		if isDelimiter(c) && start > 1 {
+			// The :: of an IPv6 address
+			if c == ':' && s[start-2] == ':' {
+				return false
+			}
+			// A delimiter and another group of hex digits
+			if isHexWord(s, start-2, -1) {
+				return false
+			}
+		}
+	}
+
+	// Check the text after the MAC address
+	if end < len(s) {
+		c := s[end]
+		if isAlphanumeric(c) {
+			return false
+		}
+		if isDelimiter(c) && end+1 < len(s) {
+			// The :: of an IPv6 address
+			if c == ':' && s[end+1] == ':' {
+				return false
+			}
+			// A delimiter and another group of hex digits
+			if isHexWord(s, end+1, 1) {
+				return false
+			}
+		}
+	}
+
+	// The MAC address stands alone
+	return true
+}
+
+// isHexWord reports whether the word of letters and digits starting at pos,
+// and read in the direction of step (1 forward, -1 backward), is a word of
+// hex digits only. It returns false if there is no word at pos.
+func isHexWord(s string, pos int, step int) bool {
+	length := 0
+	for ; pos >= 0 && pos < len(s) && isAlphanumeric(s[pos]); pos += step {
+		if !isHex(s[pos]) {
+			return false
+		}
+		length++
+	}
+	return length > 0
+}
+
+// matchAt returns the MAC address starting at the start offset of the input,
+// with groups of groupSize hex digits, if there is one. The MAC address
+// systems with the group size are tried from most specific to least specific.
+// If sameDelimiter is set, all groups must be separated by the same delimiter.
+func matchAt(s string, start int, groupSize int, sameDelimiter bool) (Match, bool) {
+	for _, ms := range macSystems {
+		if ms.groupSize != groupSize {
+			continue
+		}
+		if end, ok := ms.matchAt(s, start, sameDelimiter); ok {
+			return Match{Text: s[start:end], Offset: start, Notation: ms.notation}, true
+		}
+	}
+	return Match{}, false
+}
+
+// matchAt checks if a MAC address in the notation of the system starts at
+// the start offset of the input, and returns the offset just after it. The
+// groups may be separated by a colon, hyphen or period, and if sameDelimiter
+// is set, the same delimiter must be used between all of the groups.
+func (ms macSystem) matchAt(s string, start int, sameDelimiter bool) (int, bool) {
+	pos := start
+	for group := 0; group < ms.groupCount; group++ {
+		// Every group but the first is preceded by a delimiter
+		if group > 0 {
+			if pos >= len(s) || !isDelimiter(s[pos]) {
+				return 0, false
+			}
+			if sameDelimiter && group > 1 && s[pos] != s[start+ms.groupSize] {
+				return 0, false
+			}
+			pos++
+		}
+
+		// Each group is groupSize hex digits
+		if pos+ms.groupSize > len(s) {
+			return 0, false
+		}
+		for _, c := range []byte(s[pos : pos+ms.groupSize]) {
+			if !isHex(c) {
+				return 0, false
+			}
+		}
+		pos += ms.groupSize
+	}
+	return pos, true
+}
+
+// isHex reports whether the character is a hexadecimal digit
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// isAlphanumeric reports whether the character is a letter or a digit
+func isAlphanumeric(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// isDelimiter reports whether the character separates the groups of a MAC address
+func isDelimiter(c byte) bool {
+	return c == ':' || c == '-' || c == '.'
+}
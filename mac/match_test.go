@@ -0,0 +1,150 @@
+package mac
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestFindAllMatches tests the FindAllMatches function
+func TestFindAllMatches(t *testing.T) {
+	// Setup test cases
+	testCases := []struct {
+		name     string
+		input    string
+		expected []Match
+	}{
+		{
+			name:     "EmptyInput",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:  "DocumentOrder",
+			input: "first 0000.5e00.5301 then 00:00:5e:00:53:02",
+			expected: []Match{
+				{Text: "0000.5e00.5301", Offset: 6, Line: 1, Column: 7, Notation: CiscoNotation},
+				{Text: "00:00:5e:00:53:02", Offset: 26, Line: 1, Column: 27, Notation: OctetNotation},
+			},
+		},
+		{
+			name:  "LinesAndColumns",
+			input: "no address\n  00005e-005301\n\n00-00-5e-00-53-01 x 02:00:5e:10:00:00:00:01",
+			expected: []Match{
+				{Text: "00005e-005301", Offset: 13, Line: 2, Column: 3, Notation: HPNotation},
+				{Text: "00-00-5e-00-53-01", Offset: 28, Line: 4, Column: 1, Notation: OctetNotation},
+				{Text: "02:00:5e:10:00:00:00:01", Offset: 48, Line: 4, Column: 21, Notation: OctetNotation},
+			},
+		},
+		{
+			name:  "DuplicateAddresses",
+			input: "00:00:5e:00:53:01 00:00:5e:00:53:01",
+			expected: []Match{
+				{Text: "00:00:5e:00:53:01", Offset: 0, Line: 1, Column: 1, Notation: OctetNotation},
+				{Text: "00:00:5e:00:53:01", Offset: 18, Line: 1, Column: 19, Notation: OctetNotation},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			actual := FindAllMatches(test.input)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %+v, but got %+v", test.expected, actual)
+			}
+
+			// The offsets refer to the text in the original input
+			for _, m := range actual {
+				if test.input[m.Offset:m.End()] != m.Text {
+					t.Errorf("match %q does not refer to %q in the input", m.Text, test.input[m.Offset:m.End()])
+				}
+			}
+		})
+	}
+}
+
+// benchmarkText is a syslog-like text with MAC addresses in all notations
+var benchmarkText = strings.Repeat(
+	"Oct 18 10:00:00 sw1 %SW_MATM-4-MACFLAP_NOTIF: Host 0000.5e00.5301 in vlan 10 is flapping between port Gi1/0/1 and port Gi1/0/2\n"+
+		"Oct 18 10:00:01 dhcpd: DHCPACK on 192.0.2.10 to 00:00:5e:00:53:02 (host) via eth0\n"+
+		"Oct 18 10:00:02 kernel: eth1: link up, no address here, just text and numbers 12345 67890\n"+
+		"Oct 18 10:00:03 hp: port 12 learned 00005e-005303 and EUI-64 02-00-5e-10-00-00-00-01\n",
+	1000)
+
+// BenchmarkFindAllMatches benchmarks the FindAllMatches function
+func BenchmarkFindAllMatches(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkText)))
+	for i := 0; i < b.N; i++ {
+		FindAllMatches(benchmarkText)
+	}
+}
+
+// TestFindAllMatchesCorpus tests the FindAllMatches function on a
+// corpus of real-world lines, including lines with MAC-like text
+// that must not be found in strict mode
+func TestFindAllMatchesCorpus(t *testing.T) {
+	data, err := os.ReadFile("../testdata/mac-corpus.txt")
+	if err != nil {
+		t.Fatalf("failed to read corpus: %v", err)
+	}
+
+	for number, line := range strings.Split(string(data), "\n") {
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Split the line into the expected MAC addresses and the input
+		expectedText, input, found := strings.Cut(line, "\t")
+		if !found {
+			t.Fatalf("line %d of corpus has no tab character", number+1)
+		}
+		var expected []string
+		if expectedText != "-" {
+			expected = strings.Fields(expectedText)
+		}
+
+		// Compare the text of the MAC addresses found
+		var actual []string
+		for _, m := range FindAllMatches(input) {
+			actual = append(actual, m.Text)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("line %d: expected %q in %q, but got %q", number+1, expected, input, actual)
+		}
+	}
+}
+
+// TestScannerLoose tests that a loose scanner finds
+// MAC-like text inside longer tokens, like before
+func TestScannerLoose(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "12:34:56:78:90:12.345",
+			expected: []string{"12:34:56:78:90:12"},
+		},
+		{
+			input:    "uuid 123e4567-e89b-12d3-a456-426614174000",
+			expected: []string{"4567-e89b-12d3-a456"},
+		},
+		{
+			input:    "value x00:00:5e:00:53:01",
+			expected: []string{"00:00:5e:00:53:01"},
+		},
+	}
+
+	for _, test := range testCases {
+		var actual []string
+		for _, m := range (Scanner{Loose: true}).FindAll(test.input) {
+			actual = append(actual, m.Text)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("expected %q in %q, but got %q", test.expected, test.input, actual)
+		}
+	}
+}
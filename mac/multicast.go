@@ -0,0 +1,90 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// Errors returned when mapping between multicast groups and MAC addresses
+var ErrNotMulticastIP = errors.New("not an IPv4 or IPv6 multicast address")
+var ErrNotMulticastMac = errors.New("not an IPv4 (01:00:5e) or IPv6 (33:33) multicast MAC address")
+
+// MulticastMacFromIP returns the MAC address of an IPv4 or IPv6 multicast
+// group, as colon-separated hex digits. IPv4 groups (224.0.0.0/4) map the
+// low 23 bits of the group onto 01:00:5e:00:00:00 (RFC 1112), so that
+// 239.1.1.1 is 01:00:5e:01:01:01. IPv6 groups (ff00::/8) map the low 32
+// bits of the group onto 33:33:00:00:00:00 (RFC 2464).
+func MulticastMacFromIP(ip string) (string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !addr.IsMulticast() {
+		return "", fmt.Errorf("%w: %q", ErrNotMulticastIP, ip)
+	}
+
+	// Map the IPv4 group (including IPv4-mapped IPv6 addresses)
+	if addr.Is4() || addr.Is4In6() {
+		b := addr.As4()
+		return fromOctets([]byte{0x01, 0x00, 0x5e, b[1] & 0x7f, b[2], b[3]}), nil
+	}
+
+	// Map the IPv6 group
+	b := addr.As16()
+	return fromOctets([]byte{0x33, 0x33, b[12], b[13], b[14], b[15]}), nil
+}
+
+// MulticastIPsFromMac returns the multicast groups that map to the MAC
+// address. Since only 23 of the 28 group bits of IPv4 are mapped, each
+// IPv4 multicast MAC address is shared by 32 groups, which are returned in
+// order, as in 224.1.1.1, 224.129.1.1, ..., 239.129.1.1. Since only 32 of
+// the 112 group bits of IPv6 are mapped, the IPv6 groups are countless,
+// and only the group with link-local scope (ff02::/16) is returned.
+// For 33:33:ff addresses, this is the solicited-node group of NDP.
+func MulticastIPsFromMac(macAddress string) ([]netip.Addr, error) {
+	o, err := octetsOf(macAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(o) == 6 && o[0] == 0x01 && o[1] == 0x00 && o[2] == 0x5e && o[3]&0x80 == 0:
+		// The 32 IPv4 groups in 224.0.0.0/4 with the low 23 bits
+		groups := make([]netip.Addr, 0, 32)
+		for first := byte(224); first <= 239; first++ {
+			for _, high := range []byte{0x00, 0x80} {
+				groups = append(groups, netip.AddrFrom4([4]byte{first, high | o[3], o[4], o[5]}))
+			}
+		}
+		return groups, nil
+	case len(o) == 6 && o[0] == 0x33 && o[1] == 0x33:
+		// The link-local IPv6 group with the low 32 bits, which is the
+		// solicited-node group (ff02::1:ff00:0/104) for 33:33:ff
+		b := [16]byte{0xff, 0x02}
+		copy(b[12:], o[2:])
+		if o[2] == 0xff {
+			b[11] = 0x01
+		}
+		return []netip.Addr{netip.AddrFrom16(b)}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotMulticastMac, macAddress)
+}
@@ -0,0 +1,80 @@
+package mac
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMulticastMacFromIP tests the MulticastMacFromIP function
+func TestMulticastMacFromIP(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		expected string
+		err      error
+	}{
+		{"239.1.1.1", "01:00:5e:01:01:01", nil},
+		{"224.129.1.1", "01:00:5e:01:01:01", nil},
+		{"239.255.255.250", "01:00:5e:7f:ff:fa", nil},
+		{"224.0.0.251", "01:00:5e:00:00:fb", nil},
+		{"::ffff:239.1.1.1", "01:00:5e:01:01:01", nil},
+		{"ff02::1", "33:33:00:00:00:01", nil},
+		{"ff02::1:ff12:3456", "33:33:ff:12:34:56", nil},
+		{"ff05::1:3", "33:33:00:01:00:03", nil},
+		{"192.0.2.1", "", ErrNotMulticastIP},
+		{"2001:db8::1", "", ErrNotMulticastIP},
+		{"not an ip", "", ErrNotMulticastIP},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.ip, func(t *testing.T) {
+			result, err := MulticastMacFromIP(test.ip)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+// TestMulticastIPsFromMac tests the MulticastIPsFromMac function
+func TestMulticastIPsFromMac(t *testing.T) {
+	// The 32 IPv4 groups that share a MAC address
+	groups, err := MulticastIPsFromMac("01-00-5E-01-01-01")
+	if err != nil {
+		t.Fatalf("error returned from MulticastIPsFromMac(): %v", err)
+	}
+	if len(groups) != 32 {
+		t.Fatalf("expected 32 groups, but got %d", len(groups))
+	}
+	for i, expected := range map[int]string{0: "224.1.1.1", 1: "224.129.1.1", 2: "225.1.1.1", 30: "239.1.1.1", 31: "239.129.1.1"} {
+		if groups[i].String() != expected {
+			t.Errorf("expected group %d to be %s, but got %s", i, expected, groups[i])
+		}
+	}
+
+	// Each of the groups maps back to the MAC address
+	for _, group := range groups {
+		if mac, _ := MulticastMacFromIP(group.String()); mac != "01:00:5e:01:01:01" {
+			t.Errorf("expected %s to map to 01:00:5e:01:01:01, but got %s", group, mac)
+		}
+	}
+
+	// The link-local IPv6 groups
+	groups, err = MulticastIPsFromMac("33:33:00:00:00:fb")
+	if err != nil || len(groups) != 1 || groups[0].String() != "ff02::fb" {
+		t.Errorf("expected [ff02::fb], but got %v (%v)", groups, err)
+	}
+	groups, err = MulticastIPsFromMac("3333.ff12.3456")
+	if err != nil || len(groups) != 1 || groups[0].String() != "ff02::1:ff12:3456" {
+		t.Errorf("expected [ff02::1:ff12:3456], but got %v (%v)", groups, err)
+	}
+
+	// MAC addresses that are not mapped from multicast groups
+	for _, macAddress := range []string{"01:00:5e:80:00:01", "00:00:5e:00:53:01", "33:33:00:00:00:00:00:01"} {
+		if _, err := MulticastIPsFromMac(macAddress); !errors.Is(err, ErrNotMulticastMac) {
+			t.Errorf("expected ErrNotMulticastMac for %s, but got %v", macAddress, err)
+		}
+	}
+}
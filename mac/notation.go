@@ -0,0 +1,314 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidNotation is returned when parsing an unknown notation name
+var ErrInvalidNotation = errors.New("invalid notation; must be octet, cisco, hp, bare, space, hex or decimal")
+
+// Notation is the notation a MAC address was written in, told
+// apart by the grouping of the hex digits of the address.
+type Notation int
+
+const (
+	// OctetNotation is groups of two hex digits, as in 00:00:5e:00:53:01
+	OctetNotation Notation = iota
+	// CiscoNotation is groups of four hex digits, as in 0000.5e00.5301
+	CiscoNotation
+	// HPNotation is groups of six hex digits, as in 00005e-005301
+	HPNotation
+	// BareNotation is twelve hex digits without delimiters, as in 00005E005301
+	BareNotation
+	// SpaceNotation is six octets separated by spaces, as in 00 00 5e 00 53 01
+	SpaceNotation
+	// HexNotation is twelve hex digits with a 0x prefix, as in 0x00005e005301
+	HexNotation
+	// DecimalNotation is six octets in dotted decimal, as in 0.0.94.0.83.1,
+	// the notation of the MAC address index of SNMP OIDs in BRIDGE-MIB
+	DecimalNotation
+)
+
+// notationNames maps each notation to its name
+var notationNames = map[Notation]string{
+	OctetNotation:   "octet",
+	CiscoNotation:   "cisco",
+	HPNotation:      "hp",
+	BareNotation:    "bare",
+	SpaceNotation:   "space",
+	HexNotation:     "hex",
+	DecimalNotation: "decimal",
+}
+
+// String returns the name of the notation
+func (n Notation) String() string {
+	if name, ok := notationNames[n]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseNotation returns the notation with the specified name
+func ParseNotation(name string) (Notation, error) {
+	for n, notationName := range notationNames {
+		if strings.EqualFold(name, notationName) {
+			return n, nil
+		}
+	}
+	return 0, ErrInvalidNotation
+}
+
+// accepts reports whether the scanner recognizes the notation
+func (sc Scanner) accepts(n Notation) bool {
+	for _, accepted := range sc.Accept {
+		if accepted == n {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAccepted returns the MAC address starting at the start offset of the
+// input in one of the notations that are only recognized when accepted. The
+// run of hex digits at the start offset ends at runEnd.
+func (sc Scanner) matchAccepted(s string, start int, runEnd int) (Match, bool) {
+	// Nothing to do if no extra notations are accepted
+	if len(sc.Accept) == 0 {
+		return Match{}, false
+	}
+
+	// Dotted decimal octets may end an SNMP OID, so they are
+	// not required to stand alone like the other notations. Six
+	// groups of two hex digits, as in 00.11.22.33.44.55, are read
+	// as hex, since they are also a MAC address in octet notation.
+	if sc.accepts(DecimalNotation) {
+		if end, ok := matchDecimalOctets(s, start); ok && !isPeriodHexOctets(s[start:end]) {
+			return Match{Text: s[start:end], Offset: start, Notation: DecimalNotation}, true
+		}
+	}
+
+	// Candidates for each of the accepted notations
+	end := 0
+	var notation Notation
+	switch {
+	case sc.accepts(HexNotation) && runEnd-start == 1 && s[start] == '0' &&
+		runEnd < len(s) && (s[runEnd] == 'x' || s[runEnd] == 'X') &&
+		hexRunLength(s, runEnd+1) == 12:
+		// 0x followed by twelve hex digits
+		end, notation = runEnd+13, HexNotation
+	case sc.accepts(BareNotation) && runEnd-start == 12 &&
+		isPlausibleHex(s[start:runEnd], sc.Loose):
+		// Twelve hex digits
+		end, notation = runEnd, BareNotation
+	case sc.accepts(SpaceNotation) && runEnd-start == 2:
+		// Six octets separated by single spaces
+		var ok bool
+		if end, ok = matchSpaceOctets(s, start, sc.Loose); !ok {
+			return Match{}, false
+		}
+		notation = SpaceNotation
+	default:
+		return Match{}, false
+	}
+
+	// The address must stand alone, since these notations
+	// are easily confused with other hex numbers
+	if !isStandalone(s, start, end) {
+		return Match{}, false
+	}
+
+	return Match{Text: s[start:end], Offset: start, Notation: notation}, true
+}
+
+// matchSpaceOctets returns the offset just after six octets of hex digits
+// separated by single spaces, starting at the start offset of the input.
+// Octets in hex dumps are not matched, so the octets must not be preceded
+// or followed by more octets, and must look like a MAC address. If loose is
+// set, octets that are all decimal digits are not matched.
+func matchSpaceOctets(s string, start int, loose bool) (int, bool) {
+	// Read the six octets
+	var digits strings.Builder
+	pos := start
+	for octet := 0; octet < 6; octet++ {
+		if octet > 0 {
+			if pos >= len(s) || s[pos] != ' ' {
+				return 0, false
+			}
+			pos++
+		}
+		if hexRunLength(s, pos) != 2 {
+			return 0, false
+		}
+		digits.WriteString(s[pos : pos+2])
+		pos += 2
+	}
+
+	// Reject octets that are part of a longer row of octets, as in a hex dump
+	if isOctetBefore(s, start) || isOctetAfter(s, pos) {
+		return 0, false
+	}
+
+	// Reject octets that do not look like a MAC address
+	if !isPlausibleHex(digits.String(), loose) {
+		return 0, false
+	}
+	return pos, true
+}
+
+// isPeriodHexOctets returns true if the text is six groups
+// of exactly two hex digits separated by periods
+func isPeriodHexOctets(text string) bool {
+	groups := strings.Split(text, ".")
+	if len(groups) != 6 {
+		return false
+	}
+	for _, group := range groups {
+		if len(group) != 2 || hexRunLength(group, 0) != 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// matchDecimalOctets returns the offset just after six octets in dotted
+// decimal starting at the start offset of the input. The octets may be the
+// end of a longer dotted decimal number, as in the SNMP OID
+// 1.3.6.1.2.1.17.4.3.1.1.0.0.94.0.83.1, but must not be followed by more.
+func matchDecimalOctets(s string, start int) (int, bool) {
+	// The first octet must not be part of a longer word
+	if start > 0 && isAlphanumeric(s[start-1]) {
+		return 0, false
+	}
+
+	// Read the six octets
+	pos := start
+	for octet := 0; octet < 6; octet++ {
+		if octet > 0 {
+			if pos >= len(s) || s[pos] != '.' {
+				return 0, false
+			}
+			pos++
+		}
+		length := decimalRunLength(s, pos)
+		if length == 0 || length > 3 {
+			return 0, false
+		}
+		if value, _ := strconv.Atoi(s[pos : pos+length]); value > 255 {
+			return 0, false
+		}
+		pos += length
+	}
+
+	// Reject octets followed by a word or another dotted decimal number
+	if pos < len(s) && (isAlphanumeric(s[pos]) || s[pos] == '.' && decimalRunLength(s, pos+1) > 0) {
+		return 0, false
+	}
+	return pos, true
+}
+
+// decimalRunLength returns the number of decimal digits starting at the offset
+func decimalRunLength(s string, pos int) int {
+	length := 0
+	for pos+length < len(s) && '0' <= s[pos+length] && s[pos+length] <= '9' {
+		length++
+	}
+	return length
+}
+
+// isOctetBefore reports whether the word before the spaces before the
+// offset of the input is an octet of two hex digits. The last group of a
+// delimited MAC address, as in 00:1a:2b:3c:4d:5e, is not an octet.
+func isOctetBefore(s string, pos int) bool {
+	// Skip the spaces before the offset
+	end := pos
+	for end > 0 && s[end-1] == ' ' {
+		end--
+	}
+	if end == pos {
+		return false
+	}
+
+	// Check that the word is two hex digits
+	start := end
+	for start > 0 && isAlphanumeric(s[start-1]) {
+		start--
+	}
+	if start > 0 && isDelimiter(s[start-1]) {
+		return false
+	}
+	return end-start == 2 && hexRunLength(s, start) == 2
+}
+
+// isOctetAfter reports whether the word after the spaces after the
+// offset of the input is an octet of two hex digits. The first group of a
+// delimited MAC address, as in 00:1a:2b:3c:4d:5e, is not an octet.
+func isOctetAfter(s string, pos int) bool {
+	// Skip the spaces after the offset
+	start := pos
+	for start < len(s) && s[start] == ' ' {
+		start++
+	}
+	if start == pos {
+		return false
+	}
+
+	// Check that the word is two hex digits
+	end := start
+	for end < len(s) && isAlphanumeric(s[end]) {
+		end++
+	}
+	if end < len(s) && isDelimiter(s[end]) {
+		return false
+	}
+	return end-start == 2 && hexRunLength(s, start) == 2
+}
+
+// hexRunLength returns the number of hex digits starting at the offset
+func hexRunLength(s string, pos int) int {
+	length := 0
+	for pos+length < len(s) && isHex(s[pos+length]) {
+		length++
+	}
+	return length
+}
+
+// isPlausibleHex reports whether the hex digits look like a MAC address
+// rather than any other number. Digits with both upper and lower case
+// letters, such as hashes and identifiers, are not plausible. If
+// rejectDecimal is set, digits that are all decimal, such as timestamps
+// and phone numbers, are not plausible either.
+func isPlausibleHex(digits string, rejectDecimal bool) bool {
+	hasLetter, hasUpper, hasLower := false, false, false
+	for i := 0; i < len(digits); i++ {
+		switch c := digits[i]; {
+		case 'a' <= c && c <= 'f':
+			hasLetter, hasLower = true, true
+		case 'A' <= c && c <= 'F':
+			hasLetter, hasUpper = true, true
+		}
+	}
+	return (hasLetter || !rejectDecimal) && !(hasUpper && hasLower)
+}
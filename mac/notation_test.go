@@ -0,0 +1,261 @@
+package mac
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseNotation tests the ParseNotation function
+func TestParseNotation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected Notation
+		err      error
+	}{
+		{"octet", OctetNotation, nil},
+		{"cisco", CiscoNotation, nil},
+		{"hp", HPNotation, nil},
+		{"bare", BareNotation, nil},
+		{"Space", SpaceNotation, nil},
+		{"HEX", HexNotation, nil},
+		{"decimal", DecimalNotation, nil},
+		{"dotted", 0, ErrInvalidNotation},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := ParseNotation(test.name)
+			if err != test.err {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if actual != test.expected {
+				t.Errorf("expected %v, but got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+// TestScannerAccept tests that the notations that are only recognized
+// when accepted are found, and that look-alike hex numbers are not
+func TestScannerAccept(t *testing.T) {
+	all := []Notation{BareNotation, SpaceNotation, HexNotation}
+
+	testCases := []struct {
+		name     string
+		accept   []Notation
+		loose    bool
+		input    string
+		expected []Match
+	}{
+		{
+			name:     "NotAccepted",
+			input:    "001A2B3C4D5E 00 1a 2b 3c 4d 5e 0x001a2b3c4d5e",
+			expected: nil,
+		},
+		{
+			name:   "Bare",
+			accept: all,
+			input:  "Calling-Station-Id = \"001A2B3C4D5E\"",
+			expected: []Match{
+				{Text: "001A2B3C4D5E", Offset: 22, Line: 1, Column: 23, Notation: BareNotation},
+			},
+		},
+		{
+			name:   "Space",
+			accept: all,
+			input:  "hwaddr 00 1a 2b 3c 4d 5e, done",
+			expected: []Match{
+				{Text: "00 1a 2b 3c 4d 5e", Offset: 7, Line: 1, Column: 8, Notation: SpaceNotation},
+			},
+		},
+		{
+			name:   "Hex",
+			accept: all,
+			input:  "mac=0x001a2b3c4d5e;",
+			expected: []Match{
+				{Text: "0x001a2b3c4d5e", Offset: 4, Line: 1, Column: 5, Notation: HexNotation},
+			},
+		},
+		{
+			name:   "OnlyTheAcceptedNotation",
+			accept: []Notation{HexNotation},
+			input:  "001A2B3C4D5E 0X001A2B3C4D5E",
+			expected: []Match{
+				{Text: "0X001A2B3C4D5E", Offset: 13, Line: 1, Column: 14, Notation: HexNotation},
+			},
+		},
+		{
+			name:   "WithDelimitedNotations",
+			accept: all,
+			input:  "001A2B3C4D5E and 00:1a:2b:3c:4d:5f",
+			expected: []Match{
+				{Text: "001A2B3C4D5E", Offset: 0, Line: 1, Column: 1, Notation: BareNotation},
+				{Text: "00:1a:2b:3c:4d:5f", Offset: 17, Line: 1, Column: 18, Notation: OctetNotation},
+			},
+		},
+		{
+			name:   "BareDecimalDigits",
+			accept: all,
+			input:  "serial 001122334455",
+			expected: []Match{
+				{Text: "001122334455", Offset: 7, Line: 1, Column: 8, Notation: BareNotation},
+			},
+		},
+		{
+			name:     "BareDecimalDigitsLoose",
+			accept:   all,
+			loose:    true,
+			input:    "timestamp 202310181020 phone 004670123456",
+			expected: nil,
+		},
+		{
+			name:     "BareMixedCase",
+			accept:   all,
+			input:    "token 0a1B2c3D4e5F",
+			expected: nil,
+		},
+		{
+			name:     "BareLongerHex",
+			accept:   all,
+			input:    "commit 2fd4e1c67a2d28fced849ee1bb76e7391b93eb12 and 001a2b3c4d5e6f",
+			expected: nil,
+		},
+		{
+			name:   "SpaceDecimalDigits",
+			accept: all,
+			input:  "hwaddr 00 11 22 33 44 55",
+			expected: []Match{
+				{Text: "00 11 22 33 44 55", Offset: 7, Line: 1, Column: 8, Notation: SpaceNotation},
+			},
+		},
+		{
+			name:     "SpaceDecimalDigitsLoose",
+			accept:   all,
+			loose:    true,
+			input:    "hwaddr 00 11 22 33 44 55",
+			expected: nil,
+		},
+		{
+			name:   "SpaceAfterDelimited",
+			accept: all,
+			input:  "00:1a:2b:3c:4d:5e 00 1a 2b 3c 4d 5f",
+			expected: []Match{
+				{Text: "00:1a:2b:3c:4d:5e", Offset: 0, Line: 1, Column: 1, Notation: OctetNotation},
+				{Text: "00 1a 2b 3c 4d 5f", Offset: 18, Line: 1, Column: 19, Notation: SpaceNotation},
+			},
+		},
+		{
+			name:   "SpaceBeforeDelimited",
+			accept: all,
+			input:  "00 1a 2b 3c 4d 5f 00:1a:2b:3c:4d:5e",
+			expected: []Match{
+				{Text: "00 1a 2b 3c 4d 5f", Offset: 0, Line: 1, Column: 1, Notation: SpaceNotation},
+				{Text: "00:1a:2b:3c:4d:5e", Offset: 18, Line: 1, Column: 19, Notation: OctetNotation},
+			},
+		},
+		{
+			name:     "SpaceHexDump",
+			accept:   all,
+			input:    "00000010  00 1a 2b 3c 4d 5e 08 00  45 00 00 54 00 00 40 00",
+			expected: nil,
+		},
+		{
+			name:     "SpaceHexDumpLastRow",
+			accept:   all,
+			input:    "00000020  ff ff 00 1a 2b 3c 4d 5e",
+			expected: nil,
+		},
+		{
+			name:   "DecimalOid",
+			accept: []Notation{DecimalNotation},
+			input:  "SNMPv2-SMI::mib-2.17.4.3.1.1.0.26.43.60.77.94 = Hex-STRING: 00 1A 2B 3C 4D 5E",
+			expected: []Match{
+				{Text: "0.26.43.60.77.94", Offset: 29, Line: 1, Column: 30, Notation: DecimalNotation},
+			},
+		},
+		{
+			name:   "DecimalStandalone",
+			accept: []Notation{DecimalNotation},
+			input:  "index 0.0.94.0.83.1.",
+			expected: []Match{
+				{Text: "0.0.94.0.83.1", Offset: 6, Line: 1, Column: 7, Notation: DecimalNotation},
+			},
+		},
+		{
+			name:   "DecimalPeriodHexOctets",
+			accept: []Notation{DecimalNotation},
+			input:  "mac 00.11.22.33.44.55 index 0.0.94.0.83.1",
+			expected: []Match{
+				{Text: "00.11.22.33.44.55", Offset: 4, Line: 1, Column: 5, Notation: OctetNotation},
+				{Text: "0.0.94.0.83.1", Offset: 28, Line: 1, Column: 29, Notation: DecimalNotation},
+			},
+		},
+		{
+			name:     "DecimalNotOctets",
+			accept:   []Notation{DecimalNotation},
+			input:    "ip 192.0.2.1 version 1.2.3.4.5.256 build 1.2.3.4.5.6a",
+			expected: nil,
+		},
+		{
+			name:     "HexTooShort",
+			accept:   all,
+			input:    "flags 0x1a2b3c4d 0x001a2b3c4d5e6f",
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			actual := Scanner{Loose: test.loose, Accept: test.accept}.FindAll(test.input)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %+v, but got %+v", test.expected, actual)
+			}
+		})
+	}
+}
+
+// TestFormatAcceptedNotations tests formatting MAC addresses
+// found in the notations that are only recognized when accepted
+func TestFormatAcceptedNotations(t *testing.T) {
+	testCases := []struct {
+		macAddress string
+		format     MacFormat
+		expected   string
+	}{
+		{"001A2B3C4D5E", MacFormat{Lower, Colon, OriginalGroupSize}, "00:1a:2b:3c:4d:5e"},
+		{"001A2B3C4D5E", MacFormat{OriginalCase, OriginalDelim, OriginalGroupSize}, "001A2B3C4D5E"},
+		{"00 1a 2b 3c 4d 5e", MacFormat{OriginalCase, OriginalDelim, OriginalGroupSize}, "00 1a 2b 3c 4d 5e"},
+		{"00 1a 2b 3c 4d 5e", MacFormat{Upper, Dot, GroupSizeFour}, "001A.2B3C.4D5E"},
+		{"0x001a2b3c4d5e", MacFormat{Upper, Hyphen, GroupSizeTwo}, "00-1A-2B-3C-4D-5E"},
+		{"0x001a2b3c4d5e", MacFormat{OriginalCase, OriginalDelim, OriginalGroupSize}, "001a2b3c4d5e"},
+	}
+
+	for _, test := range testCases {
+		actual, err := FormatMacAddress(test.macAddress, test.format)
+		if err != nil {
+			t.Errorf("error returned from FormatMacAddress(%q): %v", test.macAddress, err)
+		}
+		if actual != test.expected {
+			t.Errorf("expected %q, but got %q", test.expected, actual)
+		}
+	}
+
+	// The OUI of a MAC address in hex notation excludes the prefix
+	assignment, err := ExtractOuiFromMac("0x001a2b3c4d5e")
+	if err != nil || assignment != "001A2B" {
+		t.Errorf("expected 001A2B, but got %q (%v)", assignment, err)
+	}
+}
+
+// TestMatchAddress tests that matches in dotted decimal are
+// converted to hex digits, and others are kept as found
+func TestMatchAddress(t *testing.T) {
+	decimal := Match{Text: "0.26.43.60.77.94", Notation: DecimalNotation}
+	if address := decimal.Address(); address != "00:1a:2b:3c:4d:5e" {
+		t.Errorf("expected 00:1a:2b:3c:4d:5e, but got %q", address)
+	}
+	cisco := Match{Text: "001A.2B3C.4D5E", Notation: CiscoNotation}
+	if address := cisco.Address(); address != "001A.2B3C.4D5E" {
+		t.Errorf("expected 001A.2B3C.4D5E, but got %q", address)
+	}
+}
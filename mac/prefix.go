@@ -0,0 +1,214 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPrefix is returned when parsing a malformed MAC address prefix
+var ErrInvalidPrefix = errors.New("invalid MAC address prefix; must be address/bits or a pattern such as 00:1a:2b:*")
+
+// Prefix is a block of MAC addresses sharing the first Bits bits, written
+// in CIDR style as 00:1a:2b:00:00:00/28. Prefixes of EUI-48 addresses
+// only contain EUI-48 addresses, and likewise for EUI-64.
+type Prefix struct {
+	value  uint64
+	bits   int
+	length int
+}
+
+// ParsePrefix parses a MAC address prefix in CIDR style, as in
+// 00:1a:2b:00:00:00/28, or as a pattern ending with a wildcard, as in
+// 00:1A:2B:* or 001a.2b*, where each hex digit before the wildcard is
+// four bits of the prefix. A MAC address without a length is a prefix
+// of only that address. Bits after the prefix length are ignored.
+func ParsePrefix(s string) (Prefix, error) {
+	// A pattern ending with a wildcard is an EUI-48 prefix
+	if pattern, ok := strings.CutSuffix(s, "*"); ok {
+		digits := cleanMacAddress(pattern)
+		if len(digits) > 12 || strings.Trim(pattern, "0123456789abcdefABCDEF:-.") != "" {
+			return Prefix{}, fmt.Errorf("%w: %q", ErrInvalidPrefix, s)
+		}
+		value, _ := strconv.ParseUint("0"+digits+strings.Repeat("0", 12-len(digits)), 16, 64)
+		return Prefix{value: value, bits: 4 * len(digits), length: 6}, nil
+	}
+
+	// Split the MAC address from the prefix length
+	address, length, hasLength := strings.Cut(s, "/")
+	if err := Validate(address); err != nil {
+		return Prefix{}, fmt.Errorf("%w: %q: %v", ErrInvalidPrefix, s, err)
+	}
+	octets, _ := octetsOf(address)
+	p := Prefix{value: octetsToUint(octets), bits: 8 * len(octets), length: len(octets)}
+	if hasLength {
+		n, err := strconv.Atoi(length)
+		if err != nil || n < 0 || n > p.bits {
+			return Prefix{}, fmt.Errorf("%w: %q: length must be 0 to %d", ErrInvalidPrefix, s, p.bits)
+		}
+		p.bits = n
+	}
+
+	// Clear the bits after the prefix length
+	p.value &= p.mask()
+	return p, nil
+}
+
+// mask returns the bits of the prefix as a mask of the value
+func (p Prefix) mask() uint64 {
+	if p.bits == 0 {
+		return 0
+	}
+	return ^uint64(0) << (64 - p.bits) >> (64 - 8*p.length)
+}
+
+// Bits returns the length of the prefix in bits
+func (p Prefix) Bits() int {
+	return p.bits
+}
+
+// String returns the prefix in CIDR style, as in 00:1a:2b:00:00:00/28
+func (p Prefix) String() string {
+	return fmt.Sprintf("%s/%d", fromOctets(uintToOctets(p.value, p.length)), p.bits)
+}
+
+// Contains reports whether the MAC address is in the block of the prefix.
+// It is false for invalid MAC addresses and addresses of another length.
+func (p Prefix) Contains(macAddress string) bool {
+	octets, err := octetsOf(macAddress)
+	if err != nil || len(octets) != p.length {
+		return false
+	}
+	return octetsToUint(octets)&p.mask() == p.value
+}
+
+// Overlaps reports whether the blocks of the two prefixes have any MAC
+// address in common, which is when one of them contains the other
+func (p Prefix) Overlaps(other Prefix) bool {
+	if p.length != other.length {
+		return false
+	}
+	shorter := p
+	if other.bits < p.bits {
+		shorter = other
+	}
+	return p.value&shorter.mask() == other.value&shorter.mask()
+}
+
+// Summarize returns the fewest prefixes that together contain exactly the
+// MAC addresses, no more and no less, sorted by address. Invalid MAC
+// addresses are skipped, and duplicates are only counted once.
+func Summarize(macAddresses []string) []Prefix {
+	// Collect the unique values of the MAC addresses of each length
+	values := map[int][]uint64{}
+	seen := map[Prefix]bool{}
+	for _, macAddress := range macAddresses {
+		octets, err := octetsOf(macAddress)
+		if err != nil {
+			continue
+		}
+		key := Prefix{value: octetsToUint(octets), bits: 8 * len(octets), length: len(octets)}
+		if !seen[key] {
+			seen[key] = true
+			values[len(octets)] = append(values[len(octets)], key.value)
+		}
+	}
+
+	// Cover each run of consecutive MAC addresses with the largest aligned
+	// blocks that fit in the run, EUI-48 addresses before EUI-64 addresses
+	var prefixes []Prefix
+	for _, length := range []int{6, 8} {
+		run := values[length]
+		sort.Slice(run, func(i, j int) bool { return run[i] < run[j] })
+		for start := 0; start < len(run); {
+			end := start
+			for end+1 < len(run) && run[end+1] == run[end]+1 {
+				end++
+			}
+			prefixes = append(prefixes, coverRun(run[start], run[end], length)...)
+			start = end + 1
+		}
+	}
+	return prefixes
+}
+
+// coverRun returns the fewest prefixes that contain exactly the MAC
+// addresses from first to last, for MAC addresses of the length in octets
+func coverRun(first uint64, last uint64, length int) []Prefix {
+	var prefixes []Prefix
+	for {
+		// Find the largest block aligned at the first address that does
+		// not go past the last address. Since the block is aligned, the
+		// end of the block does not overflow.
+		size := bits.TrailingZeros64(first)
+		if size > 8*length {
+			size = 8 * length
+		}
+		blockEnd := func(size int) uint64 { return first + (uint64(1)<<size - 1) }
+		for size > 0 && blockEnd(size) > last {
+			size--
+		}
+		prefixes = append(prefixes, Prefix{value: first, bits: 8*length - size, length: length})
+
+		// Continue after the block, unless it ends at the last address
+		if blockEnd(size) >= last {
+			return prefixes
+		}
+		first = blockEnd(size) + 1
+	}
+}
+
+// Cover returns the longest prefix that contains all of the MAC addresses,
+// which must be valid and of the same length
+func Cover(macAddresses []string) (Prefix, error) {
+	if len(macAddresses) == 0 {
+		return Prefix{}, ErrEmptyMacAddress
+	}
+	var p Prefix
+	for i, macAddress := range macAddresses {
+		octets, err := octetsOf(macAddress)
+		if err != nil {
+			return Prefix{}, fmt.Errorf("%s: %w", macAddress, err)
+		}
+		value := octetsToUint(octets)
+		if i == 0 {
+			p = Prefix{value: value, bits: 8 * len(octets), length: len(octets)}
+			continue
+		}
+		if len(octets) != p.length {
+			return Prefix{}, ErrLengthMismatch
+		}
+
+		// Shorten the prefix to the bits in common with the MAC address
+		common := bits.LeadingZeros64((p.value ^ value) << (64 - 8*p.length))
+		if common < p.bits {
+			p.bits = common
+			p.value &= p.mask()
+		}
+	}
+	return p, nil
+}
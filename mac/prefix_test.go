@@ -0,0 +1,160 @@
+package mac
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestParsePrefix tests the ParsePrefix function and the String method
+func TestParsePrefix(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefix   string
+		expected string
+		err      error
+	}{
+		{"Cidr", "00:1a:2b:00:00:00/28", "00:1a:2b:00:00:00/28", nil},
+		{"CidrHostBits", "00-1A-2B-3C-4D-5E/24", "00:1a:2b:00:00:00/24", nil},
+		{"CidrOddLength", "00:1a:2b:ff:00:00/27", "00:1a:2b:e0:00:00/27", nil},
+		{"Address", "001a.2b3c.4d5e", "00:1a:2b:3c:4d:5e/48", nil},
+		{"EUI64", "02:00:5e:10:00:00:00:00/40", "02:00:5e:10:00:00:00:00/40", nil},
+		{"Zero", "00:1a:2b:3c:4d:5e/0", "00:00:00:00:00:00/0", nil},
+		{"Wildcard", "00:1A:2B:*", "00:1a:2b:00:00:00/24", nil},
+		{"WildcardDigit", "001a.2b3*", "00:1a:2b:30:00:00/28", nil},
+		{"WildcardAll", "*", "00:00:00:00:00:00/0", nil},
+		{"LengthTooLong", "00:1a:2b:00:00:00/49", "", ErrInvalidPrefix},
+		{"LengthNotNumber", "00:1a:2b:00:00:00/x", "", ErrInvalidPrefix},
+		{"InvalidAddress", "00:1a:2b:00:00/24", "", ErrInvalidPrefix},
+		{"WildcardNotHex", "00:1g:*", "", ErrInvalidPrefix},
+		{"WildcardTooLong", "00:1a:2b:3c:4d:5e:6f:*", "", ErrInvalidPrefix},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := ParsePrefix(test.prefix)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, but got %v", test.err, err)
+			}
+			if err == nil && p.String() != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, p.String())
+			}
+		})
+	}
+}
+
+// TestPrefixContains tests the Contains and Overlaps methods
+func TestPrefixContains(t *testing.T) {
+	p, _ := ParsePrefix("00:1a:2b:00:00:00/28")
+
+	testCases := []struct {
+		macAddress string
+		expected   bool
+	}{
+		{"00:1a:2b:00:00:00", true},
+		{"001A.2B0F.FFFF", true},
+		{"00-1a-2b-10-00-00", false},
+		{"00:1a:2c:00:00:00", false},
+		{"00:1a:2b:00:00:00:00:00", false},
+		{"not a MAC", false},
+	}
+	for _, test := range testCases {
+		if actual := p.Contains(test.macAddress); actual != test.expected {
+			t.Errorf("Contains(%q): expected %v, but got %v", test.macAddress, test.expected, actual)
+		}
+	}
+
+	overlaps := []struct {
+		other    string
+		expected bool
+	}{
+		{"00:1a:2b:*", true},
+		{"00:1a:2b:0f:00:00/32", true},
+		{"00:1a:2b:10:00:00/28", false},
+		{"00:1a:2b:00:00:00:00:00/28", false},
+	}
+	for _, test := range overlaps {
+		other, _ := ParsePrefix(test.other)
+		if actual := p.Overlaps(other); actual != test.expected {
+			t.Errorf("Overlaps(%q): expected %v, but got %v", test.other, test.expected, actual)
+		}
+		if actual := other.Overlaps(p); actual != test.expected {
+			t.Errorf("%q Overlaps: expected %v, but got %v", test.other, test.expected, actual)
+		}
+	}
+}
+
+// TestSummarize tests the Summarize and Cover functions
+func TestSummarize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		addresses []string
+		expected  []string
+		cover     string
+	}{
+		{
+			name:      "Single",
+			addresses: []string{"00:1a:2b:3c:4d:5e"},
+			expected:  []string{"00:1a:2b:3c:4d:5e/48"},
+			cover:     "00:1a:2b:3c:4d:5e/48",
+		},
+		{
+			name: "AlignedBlock",
+			addresses: []string{
+				"00:1a:2b:00:00:03", "00:1a:2b:00:00:01", "00:1a:2b:00:00:02", "00-1A-2B-00-00-00",
+			},
+			expected: []string{"00:1a:2b:00:00:00/46"},
+			cover:    "00:1a:2b:00:00:00/46",
+		},
+		{
+			name: "UnalignedRunAndDuplicates",
+			addresses: []string{
+				"00:1a:2b:00:00:01", "00:1a:2b:00:00:02", "00:1a:2b:00:00:03", "00:1a:2b:00:00:04",
+				"001a.2b00.0004", "00:1a:2b:00:00:10", "invalid",
+			},
+			expected: []string{
+				"00:1a:2b:00:00:01/48", "00:1a:2b:00:00:02/47", "00:1a:2b:00:00:04/48", "00:1a:2b:00:00:10/48",
+			},
+		},
+		{
+			name:      "EUI64AfterEUI48",
+			addresses: []string{"02:00:5e:10:00:00:00:01", "00:1a:2b:3c:4d:5e"},
+			expected:  []string{"00:1a:2b:3c:4d:5e/48", "02:00:5e:10:00:00:00:01/64"},
+		},
+		{
+			name:      "Cover",
+			addresses: []string{"00:1a:2b:00:00:01", "00:1a:2b:00:00:0e"},
+			expected:  []string{"00:1a:2b:00:00:01/48", "00:1a:2b:00:00:0e/48"},
+			cover:     "00:1a:2b:00:00:00/44",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var actual []string
+			for _, p := range Summarize(test.addresses) {
+				actual = append(actual, p.String())
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %v, but got %v", test.expected, actual)
+			}
+
+			// Check the single covering prefix
+			if test.cover != "" {
+				p, err := Cover(test.addresses)
+				if err != nil {
+					t.Fatalf("error returned from Cover(): %v", err)
+				}
+				if p.String() != test.cover {
+					t.Errorf("expected cover %q, but got %q", test.cover, p.String())
+				}
+			}
+		})
+	}
+
+	// All of the EUI-64 addresses in a single prefix
+	all := Summarize([]string{"00:00:00:00:00:00:00:00", "ff:ff:ff:ff:ff:ff:ff:ff"})
+	if len(all) != 2 || all[0].String() != "00:00:00:00:00:00:00:00/64" {
+		t.Errorf("unexpected summary of the ends of the EUI-64 range: %v", all)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+// specialAddress is a well-known MAC address, or a block of them, that is
+// reserved for a protocol or purpose rather than assigned to a device
+type specialAddress struct {
+	prefix      Prefix
+	description string
+}
+
+// specialAddresses is the registry of well-known MAC addresses. When more
+// than one entry contains a MAC address, the longest prefix is used.
+var specialAddresses = []specialAddress{
+	// Addresses with a special meaning on every network
+	newSpecialAddress("ff:ff:ff:ff:ff:ff", "Broadcast"),
+	newSpecialAddress("00:00:00:00:00:00", "Null address"),
+
+	// IEEE 802.1 reserved addresses, not forwarded by bridges
+	newSpecialAddress("01:80:c2:00:00:00/44", "IEEE 802.1 reserved multicast"),
+	newSpecialAddress("01:80:c2:00:00:00", "IEEE 802.1D STP multicast"),
+	newSpecialAddress("01:80:c2:00:00:01", "IEEE 802.3x PAUSE multicast"),
+	newSpecialAddress("01:80:c2:00:00:02", "IEEE 802.3 Slow Protocols multicast (LACP, OAM)"),
+	newSpecialAddress("01:80:c2:00:00:03", "IEEE 802.1X PAE multicast"),
+	newSpecialAddress("01:80:c2:00:00:08", "IEEE 802.1ad provider bridge STP multicast"),
+	newSpecialAddress("01:80:c2:00:00:0e", "IEEE 802.1 LLDP multicast"),
+	newSpecialAddress("01:80:c2:00:00:20/44", "IEEE 802.1 MRP/GARP multicast"),
+	newSpecialAddress("01:80:c2:00:00:21", "IEEE 802.1 GVRP multicast"),
+	newSpecialAddress("01:80:c2:00:00:30/44", "IEEE 802.1ag CFM multicast"),
+	newSpecialAddress("01:1b:19:00:00:00", "IEEE 1588 PTP multicast"),
+
+	// Vendor protocol addresses
+	newSpecialAddress("01:00:0c:cc:cc:cc", "Cisco CDP/VTP/DTP/PAgP/UDLD multicast"),
+	newSpecialAddress("01:00:0c:cc:cc:cd", "Cisco PVST+ multicast"),
+	newSpecialAddress("01:00:0c:cd:cd:cd", "Cisco STP UplinkFast multicast"),
+	newSpecialAddress("01:00:0c:00:00:00", "Cisco ISL multicast"),
+	newSpecialAddress("01:e0:52:cc:cc:cc", "Extreme EDP multicast"),
+
+	// IPv4 multicast (RFC 1112) and some of the well-known groups
+	newSpecialAddress("01:00:5e:00:00:00/25", "IPv4 multicast"),
+	newSpecialAddress("01:00:5e:00:00:01", "IPv4 multicast all hosts (224.0.0.1)"),
+	newSpecialAddress("01:00:5e:00:00:02", "IPv4 multicast all routers (224.0.0.2)"),
+	newSpecialAddress("01:00:5e:00:00:05", "IPv4 multicast OSPF routers (224.0.0.5)"),
+	newSpecialAddress("01:00:5e:00:00:06", "IPv4 multicast OSPF designated routers (224.0.0.6)"),
+	newSpecialAddress("01:00:5e:00:00:09", "IPv4 multicast RIPv2 routers (224.0.0.9)"),
+	newSpecialAddress("01:00:5e:00:00:0d", "IPv4 multicast PIM routers (224.0.0.13)"),
+	newSpecialAddress("01:00:5e:00:00:12", "IPv4 multicast VRRP (224.0.0.18)"),
+	newSpecialAddress("01:00:5e:00:00:16", "IPv4 multicast IGMPv3 (224.0.0.22)"),
+	newSpecialAddress("01:00:5e:00:00:fb", "IPv4 multicast mDNS (224.0.0.251)"),
+	newSpecialAddress("01:00:5e:00:00:fc", "IPv4 multicast LLMNR (224.0.0.252)"),
+	newSpecialAddress("01:00:5e:7f:ff:fa", "IPv4 multicast SSDP (239.255.255.250)"),
+
+	// IPv6 multicast (RFC 2464) and some of the well-known groups
+	newSpecialAddress("33:33:00:00:00:00/16", "IPv6 multicast"),
+	newSpecialAddress("33:33:00:00:00:01", "IPv6 multicast all nodes (ff02::1)"),
+	newSpecialAddress("33:33:00:00:00:02", "IPv6 multicast all routers (ff02::2)"),
+	newSpecialAddress("33:33:00:00:00:16", "IPv6 multicast MLDv2 reports (ff02::16)"),
+	newSpecialAddress("33:33:00:00:00:fb", "IPv6 multicast mDNS (ff02::fb)"),
+	newSpecialAddress("33:33:00:01:00:02", "IPv6 multicast DHCPv6 agents (ff02::1:2)"),
+	newSpecialAddress("33:33:ff:00:00:00/24", "IPv6 multicast solicited-node"),
+}
+
+// newSpecialAddress returns the special address of the prefix, which is
+// a MAC address or a block of them in CIDR style. It panics if the prefix
+// is invalid, since the registry is part of the source.
+func newSpecialAddress(prefix string, description string) specialAddress {
+	p, err := ParsePrefix(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return specialAddress{prefix: p, description: description}
+}
+
+// LookupSpecial returns the description of a well-known MAC address, such
+// as broadcast, IEEE 802.1 reserved addresses (STP, LACP, LLDP), Cisco
+// protocol addresses and IPv4 and IPv6 multicast, and whether it is one
+func LookupSpecial(macAddress string) (string, bool) {
+	var found *specialAddress
+	for i, special := range specialAddresses {
+		if special.prefix.Contains(macAddress) && (found == nil || special.prefix.Bits() > found.prefix.Bits()) {
+			found = &specialAddresses[i]
+		}
+	}
+	if found == nil {
+		return "", false
+	}
+	return found.description, true
+}
@@ -0,0 +1,36 @@
+package mac
+
+import "testing"
+
+// TestLookupSpecial tests the LookupSpecial function
+func TestLookupSpecial(t *testing.T) {
+	testCases := []struct {
+		macAddress string
+		expected   string
+		found      bool
+	}{
+		{"ff:ff:ff:ff:ff:ff", "Broadcast", true},
+		{"01:80:c2:00:00:0e", "IEEE 802.1 LLDP multicast", true},
+		{"0180.C200.0002", "IEEE 802.3 Slow Protocols multicast (LACP, OAM)", true},
+		{"01-80-C2-00-00-0A", "IEEE 802.1 reserved multicast", true},
+		{"01:80:c2:00:00:10", "", false},
+		{"01:00:0c:cc:cc:cc", "Cisco CDP/VTP/DTP/PAgP/UDLD multicast", true},
+		{"01:00:5e:7f:ff:fa", "IPv4 multicast SSDP (239.255.255.250)", true},
+		{"01:00:5e:01:01:01", "IPv4 multicast", true},
+		{"01:00:5e:80:00:00", "", false},
+		{"33:33:00:00:00:01", "IPv6 multicast all nodes (ff02::1)", true},
+		{"33:33:ff:12:34:56", "IPv6 multicast solicited-node", true},
+		{"33:33:12:34:56:78", "IPv6 multicast", true},
+		{"00:00:5e:00:53:01", "", false},
+		{"invalid", "", false},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.macAddress, func(t *testing.T) {
+			description, found := LookupSpecial(test.macAddress)
+			if description != test.expected || found != test.found {
+				t.Errorf("expected %q (%v), but got %q (%v)", test.expected, test.found, description, found)
+			}
+		})
+	}
+}
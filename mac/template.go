@@ -0,0 +1,275 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors returned when parsing and formatting with templates
+var ErrInvalidTemplate = errors.New("invalid template")
+var ErrUnknownPreset = errors.New("unknown template preset")
+
+// Formatter formats a MAC address. It is implemented by MacFormat,
+// which changes the case, delimiter and group size of the address,
+// and by Template, which renders the address into a template.
+type Formatter interface {
+	Format(macAddress string) (string, error)
+}
+
+// Format formats the MAC address with the case, delimiter and group size
+// of the format. It implements the Formatter interface for MacFormat.
+func (f MacFormat) Format(macAddress string) (string, error) {
+	return FormatMacAddress(macAddress, f)
+}
+
+// Template formats MAC addresses by replacing the placeholders of a pattern
+// with the hex digits of the address. The placeholders are:
+//
+//	{oct0} .. {oct7}   a single octet in lower case ({OCT0} in upper case)
+//	{hex}              all of the hex digits in lower case ({HEX} in upper case)
+//	{octets:SEP}       all of the octets separated by SEP ({OCTETS:SEP})
+//	{groups:N:SEP}     groups of N hex digits separated by SEP ({GROUPS:N:SEP})
+//
+// Use {{ and }} for literal braces. If Reverse is set, the octets are in
+// reverse order, and if BitReverse is set, the bits of each octet are reversed,
+// converting between canonical and bit-reversed (Token Ring) form.
+type Template struct {
+	Pattern    string
+	Reverse    bool
+	BitReverse bool
+	parts      []templatePart
+}
+
+// templatePart is either literal text or a placeholder of a template
+type templatePart struct {
+	literal   string
+	name      string
+	upper     bool
+	octet     int
+	groupSize int
+	separator string
+}
+
+// templatePresets are the named templates that can be used with Preset
+var templatePresets = map[string]Template{
+	"cisco":        {Pattern: "{groups:4:.}"},
+	"cisco-upper":  {Pattern: "{GROUPS:4:.}"},
+	"windows":      {Pattern: "{OCTETS:-}"},
+	"linux":        {Pattern: "{octets::}"},
+	"hex":          {Pattern: "0x{hex}"},
+	"c-array":      {Pattern: "{{0x{octets:, 0x}}}"},
+	"reversed":     {Pattern: "{octets::}", Reverse: true},
+	"bit-reversed": {Pattern: "{octets::}", BitReverse: true},
+}
+
+// PresetNames returns the names of the template presets, sorted
+func PresetNames() []string {
+	names := make([]string, 0, len(templatePresets))
+	for name := range templatePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns the template preset with the specified name
+func Preset(name string) (*Template, error) {
+	preset, ok := templatePresets[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w %q; must be one of %s", ErrUnknownPreset, name, strings.Join(PresetNames(), ", "))
+	}
+	return ParseTemplate(preset.Pattern, preset.Reverse, preset.BitReverse)
+}
+
+// ParseTemplate parses the template pattern and returns a Template
+// formatting MAC addresses with the byte and bit order options
+func ParseTemplate(pattern string, reverse bool, bitReverse bool) (*Template, error) {
+	t := &Template{Pattern: pattern, Reverse: reverse, BitReverse: bitReverse}
+
+	// Split the pattern into literal text and placeholders
+	var literal strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '{' && strings.HasPrefix(pattern[i:], "{{"):
+			// Escaped opening brace
+			literal.WriteByte('{')
+			i++
+		case c == '}' && strings.HasPrefix(pattern[i:], "}}"):
+			// Escaped closing brace
+			literal.WriteByte('}')
+			i++
+		case c == '{':
+			// Placeholder up to the closing brace
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed placeholder at offset %d", ErrInvalidTemplate, i)
+			}
+			part, err := parsePlaceholder(pattern[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			if literal.Len() > 0 {
+				t.parts = append(t.parts, templatePart{literal: literal.String()})
+				literal.Reset()
+			}
+			t.parts = append(t.parts, part)
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("%w: unmatched } at offset %d (use }} for a literal brace)", ErrInvalidTemplate, i)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	if literal.Len() > 0 {
+		t.parts = append(t.parts, templatePart{literal: literal.String()})
+	}
+
+	// A template without placeholders would replace every address with the same text
+	hasPlaceholder := false
+	for _, part := range t.parts {
+		if part.name != "" {
+			hasPlaceholder = true
+		}
+	}
+	if !hasPlaceholder {
+		return nil, fmt.Errorf("%w: no placeholders in %q", ErrInvalidTemplate, pattern)
+	}
+
+	return t, nil
+}
+
+// parsePlaceholder parses the text between the braces of a placeholder
+func parsePlaceholder(text string) (templatePart, error) {
+	// The name decides the case, and the arguments follow after colons
+	name, args, _ := strings.Cut(text, ":")
+	part := templatePart{name: strings.ToLower(name), upper: name == strings.ToUpper(name)}
+
+	switch {
+	case part.name == "hex" && args == "":
+		// All of the hex digits
+	case part.name == "octets" && args != "":
+		// All of the octets with a separator
+		part.separator = args
+	case part.name == "groups":
+		// Groups of hex digits with a separator
+		size, separator, ok := strings.Cut(args, ":")
+		n, err := strconv.Atoi(size)
+		if !ok || err != nil || n < 1 {
+			return templatePart{}, fmt.Errorf("%w: {%s} must be {groups:N:SEP}", ErrInvalidTemplate, text)
+		}
+		part.groupSize, part.separator = n, separator
+	case strings.HasPrefix(part.name, "oct") && args == "":
+		// A single octet
+		n, err := strconv.Atoi(part.name[3:])
+		if err != nil || n < 0 || n > 7 {
+			return templatePart{}, fmt.Errorf("%w: {%s} must be {oct0} to {oct7}", ErrInvalidTemplate, text)
+		}
+		part.name, part.octet = "oct", n
+	default:
+		return templatePart{}, fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidTemplate, text)
+	}
+
+	// Names in mixed case are ambiguous
+	if !part.upper && name != strings.ToLower(name) {
+		return templatePart{}, fmt.Errorf("%w: {%s} must be all lower or all upper case", ErrInvalidTemplate, text)
+	}
+	return part, nil
+}
+
+// Format renders the MAC address into the template. It implements
+// the Formatter interface for Template.
+func (t *Template) Format(macAddress string) (string, error) {
+	// Get the hex digits of the MAC address in lower case
+	digits := strings.ToLower(cleanMacAddress(trimHexPrefix(macAddress)))
+	if len(digits) != 12 && len(digits) != 16 {
+		return "", ErrInvalidMacAddress
+	}
+
+	// Split the hex digits into octets in the requested order
+	octets := make([]string, len(digits)/2)
+	for i := range octets {
+		octets[i] = digits[i*2 : i*2+2]
+	}
+	if t.Reverse {
+		for i, j := 0, len(octets)-1; i < j; i, j = i+1, j-1 {
+			octets[i], octets[j] = octets[j], octets[i]
+		}
+	}
+	if t.BitReverse {
+		for i, octet := range octets {
+			value, _ := strconv.ParseUint(octet, 16, 8)
+			octets[i] = fmt.Sprintf("%02x", reverseBits(byte(value)))
+		}
+	}
+	digits = strings.Join(octets, "")
+
+	// Render the parts of the template
+	var b strings.Builder
+	for _, part := range t.parts {
+		var text string
+		switch part.name {
+		case "":
+			b.WriteString(part.literal)
+			continue
+		case "oct":
+			if part.octet >= len(octets) {
+				return "", fmt.Errorf("%w: {oct%d} is out of range for %s", ErrInvalidTemplate, part.octet, macAddress)
+			}
+			text = octets[part.octet]
+		case "hex":
+			text = digits
+		case "octets":
+			text = strings.Join(octets, part.separator)
+		case "groups":
+			if len(digits)%part.groupSize != 0 {
+				return "", fmt.Errorf("%w: %d hex digits can not be split into groups of %d", ErrInvalidMacAddressLength, len(digits), part.groupSize)
+			}
+			groups := make([]string, len(digits)/part.groupSize)
+			for i := range groups {
+				groups[i] = digits[i*part.groupSize : (i+1)*part.groupSize]
+			}
+			text = strings.Join(groups, part.separator)
+		}
+		if part.upper {
+			text = strings.ToUpper(text)
+		}
+		b.WriteString(text)
+	}
+
+	return b.String(), nil
+}
+
+// reverseBits returns the octet with the order of its bits reversed
+func reverseBits(b byte) byte {
+	var r byte
+	for i := 0; i < 8; i++ {
+		r = r<<1 | b&1
+		b >>= 1
+	}
+	return r
+}
@@ -0,0 +1,118 @@
+package mac
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestTemplateFormat tests formatting MAC addresses with templates
+func TestTemplateFormat(t *testing.T) {
+	testCases := []struct {
+		name       string
+		pattern    string
+		reverse    bool
+		bitReverse bool
+		macAddress string
+		expected   string
+	}{
+		{"Octets", "{oct0}-{OCT1}-{oct2}-{OCT3}-{oct4}-{OCT5}", false, false, "00:1A:2b:3C:4d:5E", "00-1A-2b-3C-4d-5E"},
+		{"Hex", "0x{hex}", false, false, "00:1A:2B:3C:4D:5E", "0x001a2b3c4d5e"},
+		{"HexUpper", "0x{HEX}", false, false, "001a.2b3c.4d5e", "0x001A2B3C4D5E"},
+		{"OctetsSeparator", "{octets::}", false, false, "00-1A-2B-3C-4D-5E", "00:1a:2b:3c:4d:5e"},
+		{"GroupsOfFour", "{GROUPS:4:.}", false, false, "00:1a:2b:3c:4d:5e", "001A.2B3C.4D5E"},
+		{"GroupsOfThree", "{groups:3:-}", false, false, "00:1a:2b:3c:4d:5e", "001-a2b-3c4-d5e"},
+		{"EscapedBraces", "{{{octets:,}}}", false, false, "00:1a:2b:3c:4d:5e", "{00,1a,2b,3c,4d,5e}"},
+		{"Reverse", "{octets::}", true, false, "00:1a:2b:3c:4d:5e", "5e:4d:3c:2b:1a:00"},
+		{"BitReverse", "{octets::}", false, true, "00:1a:2b:3c:4d:5e", "00:58:d4:3c:b2:7a"},
+		{"ReverseAndBitReverse", "{hex}", true, true, "01:02:03:04:05:06", "60a020c04080"},
+		{"EUI64", "{octets:-}", false, false, "0200.5e10.0000.0001", "02-00-5e-10-00-00-00-01"},
+		{"HexNotation", "{octets::}", false, false, "0x001a2b3c4d5e", "00:1a:2b:3c:4d:5e"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			template, err := ParseTemplate(test.pattern, test.reverse, test.bitReverse)
+			if err != nil {
+				t.Fatalf("error returned from ParseTemplate(%q): %v", test.pattern, err)
+			}
+			actual, err := template.Format(test.macAddress)
+			if err != nil {
+				t.Fatalf("error returned from Format(%q): %v", test.macAddress, err)
+			}
+			if actual != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+// TestParseTemplateErrors tests that invalid templates are rejected
+func TestParseTemplateErrors(t *testing.T) {
+	patterns := []string{
+		"no placeholders",
+		"{hex",
+		"hex}",
+		"{unknown}",
+		"{oct8}",
+		"{Hex}",
+		"{octets}",
+		"{groups:x:-}",
+		"{groups:4}",
+	}
+
+	for _, pattern := range patterns {
+		if _, err := ParseTemplate(pattern, false, false); !errors.Is(err, ErrInvalidTemplate) {
+			t.Errorf("expected ErrInvalidTemplate for %q, but got %v", pattern, err)
+		}
+	}
+}
+
+// TestPreset tests the template presets
+func TestPreset(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"cisco", "001a.2b3c.4d5e"},
+		{"cisco-upper", "001A.2B3C.4D5E"},
+		{"windows", "00-1A-2B-3C-4D-5E"},
+		{"linux", "00:1a:2b:3c:4d:5e"},
+		{"hex", "0x001a2b3c4d5e"},
+		{"c-array", "{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}"},
+		{"reversed", "5e:4d:3c:2b:1a:00"},
+		{"bit-reversed", "00:58:d4:3c:b2:7a"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			preset, err := Preset(test.name)
+			if err != nil {
+				t.Fatalf("error returned from Preset(%q): %v", test.name, err)
+			}
+			actual, err := preset.Format("00:1A:2B:3C:4D:5E")
+			if err != nil {
+				t.Fatalf("error returned from Format(): %v", err)
+			}
+			if actual != test.expected {
+				t.Errorf("expected %q, but got %q", test.expected, actual)
+			}
+		})
+	}
+
+	// Unknown presets are rejected
+	if _, err := Preset("token-ring"); !errors.Is(err, ErrUnknownPreset) {
+		t.Errorf("expected ErrUnknownPreset, but got %v", err)
+	}
+}
+
+// TestTemplateOctetOutOfRange tests that octets beyond the
+// length of the MAC address are reported when formatting
+func TestTemplateOctetOutOfRange(t *testing.T) {
+	template, err := ParseTemplate("{oct7}", false, false)
+	if err != nil {
+		t.Fatalf("error returned from ParseTemplate(): %v", err)
+	}
+	if _, err := template.Format("00:1a:2b:3c:4d:5e"); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("expected ErrInvalidTemplate, but got %v", err)
+	}
+}
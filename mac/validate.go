@@ -0,0 +1,126 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned by Validate, describing why a MAC address is malformed
+var ErrEmptyMacAddress = errors.New("empty MAC address")
+var ErrNonHexCharacters = errors.New("non-hex characters")
+var ErrMixedDelimiters = errors.New("mixed delimiters")
+var ErrWrongLength = errors.New("wrong length; must be 12 (EUI-48) or 16 (EUI-64) hex digits")
+var ErrInconsistentGroups = errors.New("inconsistent group sizes")
+
+// Validate checks that the MAC address is well-formed, and returns an error
+// describing why it is not. A well-formed MAC address has 12 or 16 hex digits,
+// either without delimiters, with a 0x prefix, or split into groups of equal
+// size (2, 4 or 6 digits) by a single kind of delimiter (colon, hyphen, period
+// or space). The errors wrap ErrNonHexCharacters, ErrMixedDelimiters,
+// ErrWrongLength, ErrInconsistentGroups or ErrInvalidGroupSize.
+func Validate(macAddress string) error {
+	if macAddress == "" {
+		return ErrEmptyMacAddress
+	}
+
+	// A MAC address in hex notation must be 0x and only hex digits
+	hexNotation := false
+	if trimmed := trimHexPrefix(macAddress); trimmed != macAddress {
+		macAddress, hexNotation = trimmed, true
+	}
+
+	// Find the hex digits and the delimiters of the MAC address
+	var delimiters []string
+	digits := 0
+	for _, c := range macAddress {
+		switch {
+		case c < 0x80 && isHex(byte(c)):
+			digits++
+		case c < 0x80 && (isDelimiter(byte(c)) || c == ' ') && !hexNotation:
+			// Remember each kind of delimiter once
+			if !containsString(delimiters, string(c)) {
+				delimiters = append(delimiters, string(c))
+			}
+		default:
+			return fmt.Errorf("%w: %q", ErrNonHexCharacters, c)
+		}
+	}
+
+	// A single kind of delimiter must be used
+	if len(delimiters) > 1 {
+		return fmt.Errorf("%w: %s", ErrMixedDelimiters, quoteAll(delimiters))
+	}
+
+	// The MAC address must be EUI-48 or EUI-64
+	if digits != 12 && digits != 16 {
+		return fmt.Errorf("%w, got %d", ErrWrongLength, digits)
+	}
+
+	// Nothing more to check without delimiters
+	if len(delimiters) == 0 {
+		return nil
+	}
+
+	// The groups must all be of the same size
+	groups := strings.Split(macAddress, delimiters[0])
+	sizes := make([]string, len(groups))
+	consistent := true
+	for i, group := range groups {
+		sizes[i] = fmt.Sprint(len(group))
+		if len(group) != len(groups[0]) {
+			consistent = false
+		}
+	}
+	if !consistent {
+		return fmt.Errorf("%w: %s", ErrInconsistentGroups, strings.Join(sizes, "-"))
+	}
+
+	// The groups must be 2, 4 or 6 digits
+	if size := len(groups[0]); size != 2 && size != 4 && size != 6 {
+		return fmt.Errorf("%w, got %d", ErrInvalidGroupSize, size)
+	}
+
+	// The MAC address is well-formed
+	return nil
+}
+
+// containsString reports whether the list contains the string
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteAll returns the strings quoted and separated by spaces
+func quoteAll(list []string) string {
+	quoted := make([]string, len(list))
+	for i, s := range list {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,48 @@
+package mac
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestValidate tests the Validate function
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		macAddress string
+		expected   error
+	}{
+		{"Colon", "00:00:5e:00:53:01", nil},
+		{"Hyphen", "00-00-5E-00-53-01", nil},
+		{"Dot", "0000.5e00.5301", nil},
+		{"HP", "00005e-005301", nil},
+		{"Space", "00 00 5e 00 53 01", nil},
+		{"Bare", "00005E005301", nil},
+		{"Hex", "0x00005e005301", nil},
+		{"EUI64", "02:00:5e:10:00:00:00:01", nil},
+		{"EUI64Dot", "0200.5e10.0000.0001", nil},
+		{"Empty", "", ErrEmptyMacAddress},
+		{"NonHex", "00:00:5g:00:53:01", ErrNonHexCharacters},
+		{"Underscore", "00_00_5e_00_53_01", ErrNonHexCharacters},
+		{"HexWithDelimiters", "0x00:00:5e:00:53:01", ErrNonHexCharacters},
+		{"MixedDelimiters", "00:11-22.33:44:55", ErrMixedDelimiters},
+		{"TwoDelimiters", "0011.2233-4455", ErrMixedDelimiters},
+		{"TooShort", "00:00:5e:00:53", ErrWrongLength},
+		{"TooLong", "00005e0053010", ErrWrongLength},
+		{"InconsistentGroups", "0000.5e.00.53.01", ErrInconsistentGroups},
+		{"EmptyGroup", "00::00:5e:00:53:01", ErrInconsistentGroups},
+		{"GroupSizeThree", "000:05e:005:301", ErrInvalidGroupSize},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.macAddress)
+			if test.expected == nil && err != nil {
+				t.Errorf("expected %q to be valid, but got %v", test.macAddress, err)
+			}
+			if test.expected != nil && !errors.Is(err, test.expected) {
+				t.Errorf("expected error %v for %q, but got %v", test.expected, test.macAddress, err)
+			}
+		})
+	}
+}
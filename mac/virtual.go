@@ -0,0 +1,88 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package mac
+
+import "fmt"
+
+// VirtualRouter is the decoded virtual MAC address of a first-hop
+// redundancy protocol, such as VRRP, HSRP, GLBP or CARP
+type VirtualRouter struct {
+	// Protocol is VRRP, HSRP or GLBP. CARP uses the same virtual MAC
+	// addresses as VRRP, so they can not be told apart.
+	Protocol string `json:"protocol"`
+
+	// Version is the version of HSRP (1 or 2), or 0 for the other protocols
+	Version int `json:"version,omitempty"`
+
+	// Family is the IP version of VRRP (IPv4 or IPv6), or empty
+	Family string `json:"family,omitempty"`
+
+	// Group is the VRRP virtual router ID (VRID), CARP virtual host ID
+	// (VHID), HSRP group number or GLBP group number
+	Group int `json:"group"`
+
+	// Forwarder is the GLBP forwarder number, or 0 for the other protocols
+	Forwarder int `json:"forwarder,omitempty"`
+}
+
+// String returns a description of the virtual router, as in
+// "VRRP/CARP IPv4 VRID 10" or "HSRPv1 group 31"
+func (vr VirtualRouter) String() string {
+	switch vr.Protocol {
+	case "VRRP":
+		return fmt.Sprintf("VRRP/CARP %s VRID %d", vr.Family, vr.Group)
+	case "HSRP":
+		return fmt.Sprintf("HSRPv%d group %d", vr.Version, vr.Group)
+	case "GLBP":
+		return fmt.Sprintf("GLBP group %d forwarder %d", vr.Group, vr.Forwarder)
+	}
+	return vr.Protocol
+}
+
+// DecodeVirtualRouter decodes the virtual MAC address of a first-hop
+// redundancy protocol, and reports whether the MAC address is one:
+//
+//	VRRP/CARP IPv4  00:00:5e:00:01:{VRID}          (RFC 5798)
+//	VRRP IPv6       00:00:5e:00:02:{VRID}          (RFC 5798)
+//	HSRPv1          00:00:0c:07:ac:{group}
+//	HSRPv2          00:00:0c:9f:f{group, 12 bits}
+//	GLBP            00:07:b4:{group, 10 bits}{forwarder}
+func DecodeVirtualRouter(macAddress string) (VirtualRouter, bool) {
+	o, err := octetsOf(macAddress)
+	if err != nil || len(o) != 6 {
+		return VirtualRouter{}, false
+	}
+
+	switch {
+	case o[0] == 0x00 && o[1] == 0x00 && o[2] == 0x5e && o[3] == 0x00 && o[4] == 0x01:
+		return VirtualRouter{Protocol: "VRRP", Family: "IPv4", Group: int(o[5])}, true
+	case o[0] == 0x00 && o[1] == 0x00 && o[2] == 0x5e && o[3] == 0x00 && o[4] == 0x02:
+		return VirtualRouter{Protocol: "VRRP", Family: "IPv6", Group: int(o[5])}, true
+	case o[0] == 0x00 && o[1] == 0x00 && o[2] == 0x0c && o[3] == 0x07 && o[4] == 0xac:
+		return VirtualRouter{Protocol: "HSRP", Version: 1, Group: int(o[5])}, true
+	case o[0] == 0x00 && o[1] == 0x00 && o[2] == 0x0c && o[3] == 0x9f && o[4]&0xf0 == 0xf0:
+		return VirtualRouter{Protocol: "HSRP", Version: 2, Group: int(o[4]&0x0f)<<8 | int(o[5])}, true
+	case o[0] == 0x00 && o[1] == 0x07 && o[2] == 0xb4 && o[3]&0xfc == 0x00:
+		return VirtualRouter{Protocol: "GLBP", Group: int(o[3])<<8 | int(o[4]), Forwarder: int(o[5])}, true
+	}
+	return VirtualRouter{}, false
+}
@@ -0,0 +1,64 @@
+package mac
+
+import "testing"
+
+// TestDecodeVirtualRouter tests the DecodeVirtualRouter function
+func TestDecodeVirtualRouter(t *testing.T) {
+	testCases := []struct {
+		macAddress string
+		expected   VirtualRouter
+		found      bool
+		text       string
+	}{
+		{"00:00:5e:00:01:0a", VirtualRouter{Protocol: "VRRP", Family: "IPv4", Group: 10}, true, "VRRP/CARP IPv4 VRID 10"},
+		{"0000.5E00.02FF", VirtualRouter{Protocol: "VRRP", Family: "IPv6", Group: 255}, true, "VRRP/CARP IPv6 VRID 255"},
+		{"00:00:0c:07:ac:1f", VirtualRouter{Protocol: "HSRP", Version: 1, Group: 31}, true, "HSRPv1 group 31"},
+		{"00-00-0C-9F-F1-2C", VirtualRouter{Protocol: "HSRP", Version: 2, Group: 300}, true, "HSRPv2 group 300"},
+		{"0007.b400.0102", VirtualRouter{Protocol: "GLBP", Group: 1, Forwarder: 2}, true, "GLBP group 1 forwarder 2"},
+		{"00:07:b4:03:ff:01", VirtualRouter{Protocol: "GLBP", Group: 1023, Forwarder: 1}, true, "GLBP group 1023 forwarder 1"},
+		{"00:07:b4:04:00:01", VirtualRouter{}, false, ""},
+		{"00:00:5e:00:53:01", VirtualRouter{}, false, ""},
+		{"00:00:0c:9f:e0:01", VirtualRouter{}, false, ""},
+		{"02:00:5e:10:00:00:00:01", VirtualRouter{}, false, ""},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.macAddress, func(t *testing.T) {
+			vr, found := DecodeVirtualRouter(test.macAddress)
+			if vr != test.expected || found != test.found {
+				t.Fatalf("expected %+v (%v), but got %+v (%v)", test.expected, test.found, vr, found)
+			}
+			if found && vr.String() != test.text {
+				t.Errorf("expected %q, but got %q", test.text, vr.String())
+			}
+		})
+	}
+}
+
+// TestAddressBits tests the IsMulticast and IsLocallyAdministered functions
+func TestAddressBits(t *testing.T) {
+	testCases := []struct {
+		macAddress string
+		multicast  bool
+		local      bool
+	}{
+		{"00:00:5e:00:53:01", false, false},
+		{"01:00:5e:00:00:01", true, false},
+		{"02:42:ac:11:00:02", false, true},
+		{"33:33:00:00:00:01", true, true},
+	}
+
+	for _, test := range testCases {
+		multicast, err := IsMulticast(test.macAddress)
+		if err != nil || multicast != test.multicast {
+			t.Errorf("IsMulticast(%q): expected %v, but got %v (%v)", test.macAddress, test.multicast, multicast, err)
+		}
+		local, err := IsLocallyAdministered(test.macAddress)
+		if err != nil || local != test.local {
+			t.Errorf("IsLocallyAdministered(%q): expected %v, but got %v (%v)", test.macAddress, test.local, local, err)
+		}
+	}
+	if _, err := IsMulticast("invalid"); err == nil {
+		t.Errorf("expected an error for an invalid MAC address")
+	}
+}
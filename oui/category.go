@@ -0,0 +1,222 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package oui
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/bitcanon/mactool/mac"
+)
+
+// CategoryVirtual is the category of the network interfaces of hypervisors,
+// container runtimes and cloud platforms
+const CategoryVirtual = "virtual"
+
+// CategoryRule puts the MAC addresses in a prefix into a category. The
+// description names the platform, which is used in place of the vendor
+// when the prefix is not in the OUI database, as for locally administered
+// prefixes such as 52:54:00 (QEMU/KVM) and 02:42 (Docker).
+type CategoryRule struct {
+	Prefix      mac.Prefix
+	Category    string
+	Description string
+}
+
+// virtualRules are the built-in prefixes of the virtual category
+var virtualRules = []struct {
+	prefix      string
+	description string
+}{
+	{"00:50:56:*", "VMware"},
+	{"00:0c:29:*", "VMware"},
+	{"00:05:69:*", "VMware"},
+	{"00:1c:14:*", "VMware"},
+	{"00:15:5d:*", "Microsoft Hyper-V"},
+	{"00:03:ff:*", "Microsoft Virtual PC"},
+	{"52:54:00:*", "QEMU/KVM"},
+	{"00:1a:4a:*", "Red Hat KVM"},
+	{"00:16:3e:*", "Xen/LXC"},
+	{"08:00:27:*", "Oracle VirtualBox"},
+	{"0a:00:27:*", "Oracle VirtualBox host-only"},
+	{"00:1c:42:*", "Parallels"},
+	{"02:42:*", "Docker"},
+	{"00:0d:3a:*", "Microsoft Azure"},
+	{"00:22:48:*", "Microsoft Azure"},
+	{"42:01:0a:*", "Google Cloud"},
+	{"02:00:17:*", "Oracle Cloud"},
+}
+
+// organizationRules are the built-in rules putting organizations into
+// device categories, such as networking, printer and voip
+//
+//go:embed categories.rules
+var organizationRules string
+
+// organizationRule puts the organizations whose name
+// contains the pattern, as whole words, into a category
+type organizationRule struct {
+	pattern  string
+	category string
+}
+
+// Categories is a table of category rules. When more than one rule
+// contains a MAC address, the rule with the longest prefix is used.
+// MAC addresses that are not in any prefix are categorized by the
+// organization of their OUI, using the first matching organization rule.
+type Categories struct {
+	rules         []CategoryRule
+	organizations []organizationRule
+}
+
+// NewCategories returns a table of the built-in category rules, which
+// put hypervisors, container runtimes and cloud NICs in the virtual
+// category, and organizations into device categories
+func NewCategories() *Categories {
+	c := &Categories{}
+	for _, rule := range virtualRules {
+		if err := c.AddPrefix(CategoryVirtual, rule.prefix, rule.description); err != nil {
+			panic(err)
+		}
+	}
+	if err := c.LoadOrganizationRules(strings.NewReader(organizationRules)); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// LoadOrganizationRules reads rules putting organizations into categories,
+// one per line, as a category and a pattern separated by whitespace:
+//
+//	# Comments and empty lines are ignored
+//	printer      Brother Industries
+//	networking   Cisco Systems
+//
+// The rules are added before the existing ones, so that
+// they take precedence over the built-in rules.
+func (c *Categories) LoadOrganizationRules(r io.Reader) error {
+	var rules []organizationRule
+	scanner := bufio.NewScanner(r)
+	number := 0
+	for scanner.Scan() {
+		number++
+
+		// Skip comments and empty lines
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Split the line into the category and the pattern
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: missing organization pattern after category %q", number, fields[0])
+		}
+		category, pattern := fields[0], strings.Join(fields[1:], " ")
+		rules = append(rules, organizationRule{pattern: strings.ToLower(pattern), category: strings.ToLower(category)})
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	c.organizations = append(rules, c.organizations...)
+	return nil
+}
+
+// MatchOrganization returns the category of the first rule
+// matching the organization, and whether there is one
+func (c *Categories) MatchOrganization(organization string) (string, bool) {
+	organization = strings.ToLower(organization)
+	for _, rule := range c.organizations {
+		if containsWords(organization, rule.pattern) {
+			return rule.category, true
+		}
+	}
+	return "", false
+}
+
+// Classify returns the category of the MAC address and its vendor, which
+// may be nil. Prefix rules take precedence over organization rules.
+func (c *Categories) Classify(macAddress string, vendor *Oui) (string, bool) {
+	if rule, ok := c.Match(macAddress); ok {
+		return rule.Category, true
+	}
+	if vendor != nil {
+		return c.MatchOrganization(vendor.Organization)
+	}
+	return "", false
+}
+
+// containsWords returns true if s contains the pattern and neither
+// is directly preceded or followed by a letter or digit
+func containsWords(s string, pattern string) bool {
+	for start := 0; start <= len(s)-len(pattern); {
+		i := strings.Index(s[start:], pattern)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(pattern)
+		if (i == 0 || !isWordByte(s[i-1])) && (end == len(s) || !isWordByte(s[end])) {
+			return true
+		}
+		start = i + 1
+	}
+	return false
+}
+
+// isWordByte returns true if the byte is a letter or digit
+func isWordByte(b byte) bool {
+	return b >= 0x80 || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
+
+// AddPrefix adds a rule putting the MAC addresses in the prefix, such as
+// 00:50:56:* or 02:00:00:00:00:00/24, into the category
+func (c *Categories) AddPrefix(category string, prefix string, description string) error {
+	if strings.TrimSpace(category) == "" {
+		return fmt.Errorf("empty category for prefix %q", prefix)
+	}
+	p, err := mac.ParsePrefix(prefix)
+	if err != nil {
+		return err
+	}
+	c.rules = append(c.rules, CategoryRule{Prefix: p, Category: strings.ToLower(category), Description: description})
+	return nil
+}
+
+// Match returns the rule with the longest prefix containing the
+// MAC address, and whether there is one
+func (c *Categories) Match(macAddress string) (CategoryRule, bool) {
+	var found *CategoryRule
+	for i, rule := range c.rules {
+		if rule.Prefix.Contains(macAddress) && (found == nil || rule.Prefix.Bits() > found.Prefix.Bits()) {
+			found = &c.rules[i]
+		}
+	}
+	if found == nil {
+		return CategoryRule{}, false
+	}
+	return *found, true
+}
@@ -0,0 +1,129 @@
+package oui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitcanon/mactool/oui"
+)
+
+// TestCategoriesMatch tests the built-in categories and
+// that added prefixes take precedence when they are longer
+func TestCategoriesMatch(t *testing.T) {
+	categories := oui.NewCategories()
+	if err := categories.AddPrefix("Lab", "02:42:ac:11:00:00/40", "Lab containers"); err != nil {
+		t.Fatalf("AddPrefix() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		mac         string
+		category    string
+		description string
+		found       bool
+	}{
+		{"VMware", "00:50:56:01:02:03", oui.CategoryVirtual, "VMware", true},
+		{"QemuKvm", "5254.0012.3456", oui.CategoryVirtual, "QEMU/KVM", true},
+		{"VirtualBox", "08-00-27-aa-bb-cc", oui.CategoryVirtual, "Oracle VirtualBox", true},
+		{"Docker", "02:42:0a:00:00:05", oui.CategoryVirtual, "Docker", true},
+		{"LongestPrefix", "02:42:ac:11:00:02", "lab", "Lab containers", true},
+		{"NotVirtual", "00:00:5e:00:53:01", "", "", false},
+		{"Invalid", "not a mac", "", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, found := categories.Match(test.mac)
+			if found != test.found {
+				t.Fatalf("Match(%q) found = %v, want %v", test.mac, found, test.found)
+			}
+			if rule.Category != test.category || rule.Description != test.description {
+				t.Errorf("Match(%q) = %q (%q), want %q (%q)", test.mac, rule.Category, rule.Description, test.category, test.description)
+			}
+		})
+	}
+}
+
+// TestCategoriesAddPrefixInvalid tests that invalid rules are rejected
+func TestCategoriesAddPrefixInvalid(t *testing.T) {
+	categories := oui.NewCategories()
+	if err := categories.AddPrefix("lab", "00:1a:zz:*", ""); err == nil {
+		t.Errorf("AddPrefix() with invalid prefix returned no error")
+	}
+	if err := categories.AddPrefix(" ", "00:1a:2b:*", ""); err == nil {
+		t.Errorf("AddPrefix() with empty category returned no error")
+	}
+}
+
+// TestCategoriesMatchOrganization tests the built-in organization rules
+// and that loaded rules take precedence over them
+func TestCategoriesMatchOrganization(t *testing.T) {
+	categories := oui.NewCategories()
+	rules := `# Lab rules
+printer   Cisco Systems
+
+mobile    Hon Hai Precision`
+	if err := categories.LoadOrganizationRules(strings.NewReader(rules)); err != nil {
+		t.Fatalf("LoadOrganizationRules() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		organization string
+		category     string
+		found        bool
+	}{
+		{"BuiltIn", "Brother Industries, LTD.", "printer", true},
+		{"IgnoreCase", "YEALINK(XIAMEN) NETWORK TECHNOLOGY CO.,LTD.", "voip", true},
+		{"Loaded", "Hon Hai Precision Ind. Co.,Ltd.", "mobile", true},
+		{"LoadedFirst", "Cisco Systems, Inc", "printer", true},
+		{"WholeWords", "Shenzhen ABBA Technology", "", false},
+		{"Unknown", "ICANN, IANA Department", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			category, found := categories.MatchOrganization(test.organization)
+			if category != test.category || found != test.found {
+				t.Errorf("MatchOrganization(%q) = %q, %v, want %q, %v", test.organization, category, found, test.category, test.found)
+			}
+		})
+	}
+}
+
+// TestCategoriesClassify tests that prefix rules take
+// precedence over the organization of the vendor
+func TestCategoriesClassify(t *testing.T) {
+	categories := oui.NewCategories()
+
+	tests := []struct {
+		name     string
+		mac      string
+		vendor   *oui.Oui
+		category string
+		found    bool
+	}{
+		{"Prefix", "52:54:00:12:34:56", nil, oui.CategoryVirtual, true},
+		{"PrefixFirst", "00:50:56:01:02:03", &oui.Oui{Organization: "Cisco Systems, Inc"}, oui.CategoryVirtual, true},
+		{"Organization", "00:00:0c:01:02:03", &oui.Oui{Organization: "Cisco Systems, Inc"}, "networking", true},
+		{"NoVendor", "00:00:0c:01:02:03", nil, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			category, found := categories.Classify(test.mac, test.vendor)
+			if category != test.category || found != test.found {
+				t.Errorf("Classify(%q) = %q, %v, want %q, %v", test.mac, category, found, test.category, test.found)
+			}
+		})
+	}
+}
+
+// TestCategoriesLoadOrganizationRulesInvalid tests that
+// a rule without an organization pattern is rejected
+func TestCategoriesLoadOrganizationRulesInvalid(t *testing.T) {
+	categories := oui.NewCategories()
+	if err := categories.LoadOrganizationRules(strings.NewReader("printer\n")); err == nil {
+		t.Errorf("LoadOrganizationRules() with missing pattern returned no error")
+	}
+}
@@ -46,6 +46,7 @@ type FilterOptions struct {
 
 // Oui represents an OUI entry in the database
 type Oui struct {
+	Registry     string // The registry of the assignment (for example "MA-L")
 	Assignment   string // The OUI assignment (for example "1A2B3C")
 	Organization string // The organization name
 	Address      string // The organization street address
@@ -162,6 +163,7 @@ func LoadDatabase(r io.Reader) (*OuiDb, error) {
 	for _, record := range records {
 		// Create an OUI entry
 		entry := Oui{
+			Registry:     record[0],
 			Assignment:   record[1],
 			Organization: record[2],
 			Address:      record[3],
@@ -279,9 +281,17 @@ func UpdateDatabase(csvFile string) error {
 		fmt.Printf("The file '%s' could not be found.\n", csvFile)
 		fmt.Print("Would you like to download it? (Y/n): ")
 
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimRight(input, "\r\n")
+		// Only ask for confirmation if standard input is a terminal,
+		// otherwise the answer would be read from the piped data
+		// that is about to be processed
+		var input string
+		if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) != 0 {
+			reader := bufio.NewReader(os.Stdin)
+			input, _ = reader.ReadString('\n')
+			input = strings.TrimRight(input, "\r\n")
+		} else {
+			fmt.Println()
+		}
 
 		if input == "n" || input == "N" {
 			// User cancelled the download so exit the program
@@ -32,6 +32,11 @@ MA-L,58A15F,Texas Instruments,12500 TI Blvd Dallas TX US 75243`
 		t.Errorf("expected 583653, got %s", db.Entries[0].Assignment)
 	}
 
+	// Verify that the registry of the first entry was loaded correctly
+	if db.Entries[0].Registry != "MA-L" {
+		t.Errorf("expected MA-L, got %s", db.Entries[0].Registry)
+	}
+
 	// Verify that the Organization name of the first entry was loaded correctly
 	if db.Entries[0].Organization != "Apple, Inc." {
 		t.Errorf("expected Apple, Inc., got %s", db.Entries[0].Organization)
@@ -0,0 +1,122 @@
+/*
+Copyright © 2023 Mikael Schultz <bitcanon@proton.me>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrDiffLineCount is returned when the lines to compare differ in count
+var ErrDiffLineCount = errors.New("the old and new lines must be of equal count")
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// WriteUnifiedDiff writes the differences between the old and new lines in
+// unified diff format, with the file names oldName and newName in the header.
+// The lines are compared pairwise, as is the case when lines are modified in
+// place, so both slices must contain the same number of lines. If
+// noFinalNewline is set, the last line has no line ending, which is marked
+// in the diff so that it applies as a patch. Nothing is written if the
+// lines are equal.
+func WriteUnifiedDiff(out io.Writer, oldName, newName string, oldLines, newLines []string, noFinalNewline bool) error {
+	if len(oldLines) != len(newLines) {
+		return ErrDiffLineCount
+	}
+
+	// Find the indexes of the changed lines
+	var changed []int
+	for i := range oldLines {
+		if oldLines[i] != newLines[i] {
+			changed = append(changed, i)
+		}
+	}
+
+	// Nothing to write if there are no changes
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// writeLine writes the line at the index with the prefix, followed
+	// by the marker if it is the last line and has no line ending
+	writeLine := func(prefix string, line string, index int) {
+		fmt.Fprintf(out, "%s%s\n", prefix, line)
+		if noFinalNewline && index == len(oldLines)-1 {
+			fmt.Fprintln(out, "\\ No newline at end of file")
+		}
+	}
+
+	// Write the file header
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", oldName, newName)
+
+	// Group the changes into hunks, merging changes that are close
+	// enough for their context lines to touch or overlap
+	for i := 0; i < len(changed); {
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*diffContext+1 {
+			j++
+		}
+
+		// Get the range of lines in the hunk, including context
+		start := changed[i] - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := changed[j] + diffContext + 1
+		if end > len(oldLines) {
+			end = len(oldLines)
+		}
+
+		// Write the hunk header and the lines in the hunk. Since lines
+		// are only modified, the ranges are equal in the old and new lines.
+		fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start)
+		for k := start; k < end; {
+			// Write unchanged lines as context
+			if oldLines[k] == newLines[k] {
+				writeLine(" ", oldLines[k], k)
+				k++
+				continue
+			}
+
+			// Write a run of changed lines as removed lines
+			// followed by the lines replacing them
+			m := k
+			for m < end && oldLines[m] != newLines[m] {
+				m++
+			}
+			for n := k; n < m; n++ {
+				writeLine("-", oldLines[n], n)
+			}
+			for n := k; n < m; n++ {
+				writeLine("+", newLines[n], n)
+			}
+			k = m
+		}
+
+		i = j + 1
+	}
+
+	// No errors occurred
+	return nil
+}
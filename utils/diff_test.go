@@ -0,0 +1,98 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitcanon/mactool/utils"
+)
+
+// TestWriteUnifiedDiff tests the WriteUnifiedDiff function
+func TestWriteUnifiedDiff(t *testing.T) {
+	// Ten lines of input to modify in the test cases
+	lines := strings.Split("1\n2\n3\n4\n5\n6\n7\n8\n9\n10", "\n")
+
+	// modify returns a copy of the lines with the lines at the given
+	// indexes replaced by the same line with an "x" appended
+	modify := func(indexes ...int) []string {
+		modified := append([]string{}, lines...)
+		for _, i := range indexes {
+			modified[i] += "x"
+		}
+		return modified
+	}
+
+	// Setup test cases
+	testCases := []struct {
+		name           string
+		newLines       []string
+		noFinalNewline bool
+		expected       string
+	}{
+		{
+			name:     "NoChanges",
+			newLines: modify(),
+			expected: "",
+		},
+		{
+			name:     "SingleChangeAtStart",
+			newLines: modify(0),
+			expected: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+1x\n 2\n 3\n 4\n",
+		},
+		{
+			name:     "SingleChangeAtEnd",
+			newLines: modify(9),
+			expected: "--- old\n+++ new\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+10x\n",
+		},
+		{
+			name:           "ChangeAtEndWithoutNewline",
+			newLines:       modify(9),
+			noFinalNewline: true,
+			expected: "--- old\n+++ new\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n\\ No newline at end of file\n" +
+				"+10x\n\\ No newline at end of file\n",
+		},
+		{
+			name:           "ContextAtEndWithoutNewline",
+			newLines:       modify(8),
+			noFinalNewline: true,
+			expected:       "--- old\n+++ new\n@@ -6,5 +6,5 @@\n 6\n 7\n 8\n-9\n+9x\n 10\n\\ No newline at end of file\n",
+		},
+		{
+			name:     "AdjacentChanges",
+			newLines: modify(4, 5),
+			expected: "--- old\n+++ new\n@@ -2,8 +2,8 @@\n 2\n 3\n 4\n-5\n-6\n+5x\n+6x\n 7\n 8\n 9\n",
+		},
+		{
+			name:     "ChangesMergedIntoOneHunk",
+			newLines: modify(1, 7),
+			expected: "--- old\n+++ new\n@@ -1,10 +1,10 @@\n 1\n-2\n+2x\n 3\n 4\n 5\n 6\n 7\n-8\n+8x\n 9\n 10\n",
+		},
+		{
+			name:     "ChangesInSeparateHunks",
+			newLines: modify(0, 8),
+			expected: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+1x\n 2\n 3\n 4\n@@ -6,5 +6,5 @@\n 6\n 7\n 8\n-9\n+9x\n 10\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			var output strings.Builder
+			err := utils.WriteUnifiedDiff(&output, "old", "new", lines, test.newLines, test.noFinalNewline)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if output.String() != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, output.String())
+			}
+		})
+	}
+
+	// Lines of different count cannot be compared pairwise
+	t.Run("DifferentLineCount", func(t *testing.T) {
+		var output strings.Builder
+		err := utils.WriteUnifiedDiff(&output, "old", "new", lines, lines[1:], false)
+		if err != utils.ErrDiffLineCount {
+			t.Errorf("expected %v, but got %v", utils.ErrDiffLineCount, err)
+		}
+	})
+}